@@ -9,12 +9,19 @@ import (
 	"net/url"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/racoon-devel/downloader/internal/api/downloader"
 	"github.com/racoon-devel/downloader/internal/client"
+	"github.com/racoon-devel/downloader/internal/hls"
+	"github.com/racoon-devel/downloader/internal/record"
+	"github.com/racoon-devel/downloader/internal/scenario"
 	"github.com/racoon-devel/downloader/internal/server"
+	"github.com/racoon-devel/downloader/internal/task"
+	"github.com/racoon-devel/downloader/internal/utils"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
@@ -25,7 +32,18 @@ type commandLineArgs struct {
 	command        string
 	serverSettings *server.Settings
 	clientSettings *client.Settings
-	taskURL        string
+	taskURLs       []string
+	taskAction     string
+	taskID         uint64
+	taskOptions    *downloader.TaskOptions
+	taskAdmission  *downloader.AdmissionPolicy
+	topInterval    time.Duration
+	topSort        string
+	eventTaskIDs   []uint64
+	scenario       *scenario.Scenario
+	targetID       uint64
+	targetCount    uint32
+	targetRandom   bool
 }
 
 func main() {
@@ -48,7 +66,57 @@ func main() {
 
 	case "task":
 		runAsyncCommand(done, *args.clientSettings, func(client downloader.DownloaderClient) error {
-			_, err := client.AddTask(ctx, &downloader.AddTaskRequest{Url: args.taskURL})
+			request := &downloader.TaskRequest{Id: args.taskID}
+			var err error
+			switch args.taskAction {
+			case "stop":
+				_, err = client.StopTask(ctx, request)
+			case "restart":
+				_, err = client.RestartTask(ctx, request)
+			case "rm":
+				_, err = client.RemoveTask(ctx, request)
+			default:
+				if len(args.taskURLs) == 1 && args.taskAdmission == nil {
+					var resp *downloader.AddTaskResponse
+					resp, err = client.AddTask(ctx, &downloader.AddTaskRequest{Url: args.taskURLs[0], Options: args.taskOptions})
+					if err == nil {
+						log.Printf("Task added: %d", resp.Id)
+					}
+					break
+				}
+				var resp *downloader.AddTasksResponse
+				resp, err = client.AddTasks(ctx, &downloader.AddTasksRequest{
+					Urls:      args.taskURLs,
+					Options:   args.taskOptions,
+					Admission: args.taskAdmission,
+				})
+				if err == nil {
+					log.Printf("Tasks added: %v", resp.Ids)
+				}
+			}
+			return err
+		})
+
+	case "maintain":
+		runAsyncCommand(done, *args.clientSettings, func(client downloader.DownloaderClient) error {
+			if args.taskAction == "stop" {
+				_, err := client.StopMaintain(ctx, &downloader.TargetRequest{Id: args.targetID})
+				return err
+			}
+			request := &downloader.MaintainRequest{
+				Id:        args.targetID,
+				Urls:      args.taskURLs,
+				Count:     args.targetCount,
+				Options:   args.taskOptions,
+				Admission: args.taskAdmission,
+			}
+			if args.targetRandom {
+				request.Selection = downloader.MaintainRequest_RANDOM
+			}
+			resp, err := client.Maintain(ctx, request)
+			if err == nil {
+				log.Printf("Target maintained: %d", resp.Id)
+			}
 			return err
 		})
 
@@ -57,10 +125,49 @@ func main() {
 			resp, err := client.Status(ctx, &emptypb.Empty{})
 			if err == nil {
 				log.Println(server.StatDictionary(resp.Stat))
+				log.Printf("Rate: %s, received: %s", utils.FormatBitrate(resp.BytesPerSec*8), utils.FormatBytes(resp.TotalBytes))
+				if len(resp.Failures) != 0 {
+					log.Printf("Failures: %s", server.StatDictionary(resp.Failures))
+				}
+				if len(resp.FailedByReason) != 0 {
+					log.Printf("Failed by reason: %s", server.StatDictionary(resp.FailedByReason))
+				}
+				if p := resp.Playout; p != nil {
+					log.Printf("Playout: %d tasks, playing %d, startup avg %s, rebuffers %d (%s)", p.Tasks, p.Playing,
+						p.StartupDelayAvg.AsDuration().Round(time.Millisecond), p.Rebuffers,
+						p.RebufferTime.AsDuration().Round(100*time.Millisecond))
+				}
+				printTargets(os.Stdout, resp.Targets)
+				printSources(os.Stdout, resp.Sources)
+				printAnalysis(os.Stdout, resp.TaskAnalysis)
 			}
 			return err
 		})
 
+	case "list":
+		runAsyncCommand(done, *args.clientSettings, func(client downloader.DownloaderClient) error {
+			resp, err := client.ListTasks(ctx, &emptypb.Empty{})
+			if err == nil {
+				printTasks(os.Stdout, resp.Tasks)
+			}
+			return err
+		})
+
+	case "top":
+		runAsyncCommand(done, *args.clientSettings, func(client downloader.DownloaderClient) error {
+			return runTop(ctx, client, args.topInterval, args.topSort)
+		})
+
+	case "run":
+		runAsyncCommand(done, *args.clientSettings, func(client downloader.DownloaderClient) error {
+			return scenario.Run(ctx, client, args.scenario, os.Stdout)
+		})
+
+	case "events":
+		runAsyncCommand(done, *args.clientSettings, func(client downloader.DownloaderClient) error {
+			return runEvents(ctx, client, args.eventTaskIDs)
+		})
+
 	case "stop":
 		runAsyncCommand(done, *args.clientSettings, func(client downloader.DownloaderClient) error {
 			_, err := client.Stop(ctx, &emptypb.Empty{})
@@ -109,11 +216,46 @@ func (c *commandLineArgs) Parse(args []string) error {
 		if len(args) < 3 {
 			return errors.New("task URL must be set")
 		}
-		c.taskURL = args[2]
+		switch args[2] {
+		case "stop", "restart", "rm":
+			if len(args) < 4 {
+				return errors.New("task ID must be set")
+			}
+			id, err := strconv.ParseUint(args[3], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid task ID: %w", err)
+			}
+			c.taskAction = args[2]
+			c.taskID = id
+			return c.parseClientArgs(args[4:])
+		}
+		n := 2
+		for n < len(args) && !strings.HasPrefix(args[n], "-") {
+			n++
+		}
+		c.taskURLs = args[2:n]
+		return c.parseTaskArgs(args[n:])
+
+	case "maintain":
+		return c.parseMaintainArgs(args[2:])
+	case "top":
+		return c.parseTopArgs(args[2:])
+	case "events":
+		return c.parseEventsArgs(args[2:])
+	case "run":
+		if len(args) < 3 {
+			return errors.New("scenario file must be set")
+		}
+		s, err := scenario.Load(args[2])
+		if err != nil {
+			return err
+		}
+		c.scenario = s
 		return c.parseClientArgs(args[3:])
-
 	case "status":
 		fallthrough
+	case "list":
+		fallthrough
 	case "stop":
 		fallthrough
 	case "done":
@@ -127,6 +269,45 @@ func (c *commandLineArgs) parseServerArgs(args []string) error {
 	fs := flag.NewFlagSet("server", flag.ContinueOnError)
 	timeout := fs.Uint("timeout", defaultTimeoutSec, "read timeout for HTTP stream (sec)")
 	endpoint := fs.String("endpoint", defaultEndpoint, "endpoint to listen clients")
+	metricsAddr := fs.String("metrics", "", "address of HTTP listener exposing Prometheus metrics, e.g. 127.0.0.1:9100")
+	variant := fs.String("variant", "highest", "HLS variant stream: highest, lowest or max bandwidth (bps)")
+	analyze := fs.Bool("analyze", false, "check MPEG-TS continuity and PCR of received media")
+	playout := fs.Bool("playout", false, "simulate player buffer and count rebuffers")
+	var playoutBitrate, rateLimit, globalRateLimit bitrateFlag
+	tlsSettings := server.TLSSettings{}
+	fs.BoolVar(&tlsSettings.Insecure, "tls-insecure", false, "skip verification of server certificates")
+	fs.StringVar(&tlsSettings.CAFile, "tls-ca", "", "PEM bundle of trusted CAs (system pool if empty)")
+	fs.StringVar(&tlsSettings.CertFile, "tls-cert", "", "PEM client certificate for mutual TLS")
+	fs.StringVar(&tlsSettings.KeyFile, "tls-key", "", "PEM key of client certificate")
+	fs.StringVar(&tlsSettings.ServerName, "tls-server-name", "", "override of SNI and verified server name")
+	fs.StringVar(&tlsSettings.MinVersion, "tls-min-version", "", "minimal TLS version: 1.0, 1.1, 1.2 or 1.3")
+	transport := task.TransportSettings{}
+	fs.StringVar(&transport.Protocol, "http-version", task.ProtocolHTTP1, "HTTP version: http1 or http2 (h2c for plain HTTP)")
+	fs.IntVar(&transport.MaxConnsPerHost, "max-conns-per-host", 0, "limit of connections to one host (unlimited if zero)")
+	fs.IntVar(&transport.MaxIdleConnsPerHost, "max-idle-conns-per-host", 0, "limit of idle connections to one host kept for reusing")
+	fs.DurationVar(&transport.IdleTimeout, "idle-timeout", 90*time.Second, "time of keeping idle connection (unlimited if zero)")
+	fs.DurationVar(&transport.DialTimeout, "dial-timeout", 30*time.Second, "timeout of establishing TCP connection (unlimited if zero)")
+	fs.BoolVar(&transport.DisableKeepAlive, "disable-keep-alive", false, "use new connection for each HTTP request")
+	isolatedTransport := fs.Bool("isolated-transport", false, "own HTTP transport of each task instead of shared connection pool")
+	var sources sourceFlags
+	fs.Var(&sources, "source", "local IP address or interface of outgoing connections, can be repeated (round-robin across tasks)")
+	var resolve resolveFlags
+	fs.Var(&resolve, "resolve", "address of host in form host:port:addr[,addr] instead of resolving, can be repeated")
+	fs.StringVar(&transport.DNSServer, "dns-server", "", "IP address of DNS server with optional port (system resolver if empty)")
+	recordAll := fs.Bool("record", false, "write received media of all tasks to files of records directory")
+	recordDir := fs.String("record-dir", "", "records directory, recording is not available if empty")
+	recordFileDuration := fs.Duration("record-file-duration", 0, "duration of record file (unlimited if zero)")
+	var recordFileSize, recordMaxSize, capture, verifyBytes sizeFlag
+	fs.Var(&capture, "capture", "size of sample of each session saved to records directory, suffix K, M or G allowed")
+	verify := fs.Bool("verify", false, "compare hashes of content received by tasks from the same URL")
+	fs.Var(&verifyBytes, "verify-bytes", "size of the beginning of continuous stream (e.g. VOD file) hashed for verification (not hashed if zero)")
+	fs.Var(&recordFileSize, "record-file-size", "size of record file, suffix K, M or G allowed (unlimited if zero)")
+	fs.Var(&recordMaxSize, "record-max-size", "limit of total size of records, the oldest files are removed (unlimited if zero)")
+	fs.Var(&playoutBitrate, "playout-bitrate", "nominal bitrate for playout simulation (bps, taken from HLS/DASH if not set)")
+	fs.Var(&rateLimit, "rate-limit", "limit of reading rate of each task (bps, suffix k, M or G allowed)")
+	fs.Var(&globalRateLimit, "global-rate-limit", "limit of total reading rate of all tasks (bps, suffix k, M or G allowed)")
+	admission := addAdmissionFlags(fs)
+	retry := addRetryFlags(fs)
 
 	err := fs.Parse(args)
 	if err != nil {
@@ -138,17 +319,226 @@ func (c *commandLineArgs) parseServerArgs(args []string) error {
 		return err
 	}
 
+	retryPolicy, err := retry.policy()
+	if err != nil {
+		return err
+	}
+
+	variantPolicy, err := hls.ParseVariantPolicy(*variant)
+	if err != nil {
+		return err
+	}
+
+	admissionPolicy, err := admission.policy()
+	if err != nil {
+		return err
+	}
+
+	transport.Hosts = resolve.hosts()
+	if err = transport.Validate(); err != nil {
+		return err
+	}
+
+	if (*recordAll || capture != 0) && *recordDir == "" {
+		return errors.New("records directory must be set to record or capture tasks")
+	}
+
 	c.serverSettings = &server.Settings{
-		Timeout: time.Duration(*timeout) * time.Second,
-		Network: network,
-		Addr:    addr,
+		Timeout:           time.Duration(*timeout) * time.Second,
+		Retry:             retryPolicy,
+		Variant:           variantPolicy,
+		Analyze:           *analyze,
+		TLS:               tlsSettings,
+		Transport:         transport,
+		IsolatedTransport: *isolatedTransport,
+		Sources:           sources,
+		Admission:         admissionPolicy,
+		Playout:           *playout,
+		PlayoutBitrate:    float64(playoutBitrate),
+		Record:            *recordAll,
+		Capture:           int(capture),
+		Verify:            *verify,
+		VerifyBytes:       int(verifyBytes),
+		Storage: record.Settings{
+			Dir:             *recordDir,
+			MaxFileSize:     uint64(recordFileSize),
+			MaxFileDuration: *recordFileDuration,
+			MaxTotalSize:    uint64(recordMaxSize),
+		},
+		RateLimit:       float64(rateLimit),
+		GlobalRateLimit: float64(globalRateLimit),
+		Network:         network,
+		Addr:            addr,
+		MetricsAddr:     *metricsAddr,
+	}
+
+	return nil
+}
+
+func (c *commandLineArgs) parseTaskArgs(args []string) error {
+	return c.parseTaskFlags(flag.NewFlagSet("task", flag.ContinueOnError), args)
+}
+
+func (c *commandLineArgs) parseMaintainArgs(args []string) error {
+	if len(args) < 1 {
+		return errors.New("target count must be set")
+	}
+	if args[0] == "stop" {
+		if len(args) < 2 {
+			return errors.New("target ID must be set")
+		}
+		id, err := strconv.ParseUint(args[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid target ID: %w", err)
+		}
+		c.taskAction = args[0]
+		c.targetID = id
+		return c.parseClientArgs(args[2:])
 	}
 
+	count, err := strconv.ParseUint(args[0], 10, 32)
+	if err != nil {
+		return fmt.Errorf("invalid target count: %w", err)
+	}
+	c.targetCount = uint32(count)
+
+	n := 1
+	for n < len(args) && !strings.HasPrefix(args[n], "-") {
+		n++
+	}
+	c.taskURLs = args[1:n]
+	if len(c.taskURLs) == 0 {
+		return errors.New("URL pool must be set")
+	}
+
+	fs := flag.NewFlagSet("maintain", flag.ContinueOnError)
+	fs.Uint64Var(&c.targetID, "id", 0, "ID of target to update (new target is registered if zero)")
+	fs.BoolVar(&c.targetRandom, "random", false, "choose URLs of the pool randomly instead of round-robin")
+	return c.parseTaskFlags(fs, args[n:])
+}
+
+// parseTaskFlags parses options of tasks to add. Flags specific for the command may be defined in fs
+func (c *commandLineArgs) parseTaskFlags(fs *flag.FlagSet, args []string) error {
+	variant := fs.String("variant", "", "HLS variant stream: highest, lowest or max bandwidth (bps)")
+	streamType := fs.String("type", "", "stream type: http, hls, dash, tcp, udp or rtp (detected by URL if empty)")
+	analyze := fs.Bool("analyze", false, "check MPEG-TS continuity and PCR of received media")
+	playout := fs.Bool("playout", false, "simulate player buffer and count rebuffers")
+	var playoutBitrate, rateLimit bitrateFlag
+	record := fs.Bool("record", false, "write received media to files of server records directory")
+	verify := fs.Bool("verify", false, "compare hashes of content with other tasks which request the same URL")
+	isolatedTransport := fs.Bool("isolated-transport", false, "own HTTP transport instead of server connection pool")
+	var sources sourceFlags
+	fs.Var(&sources, "source", "local IP address or interface of outgoing connections, can be repeated (round-robin across tasks)")
+	var resolve resolveFlags
+	fs.Var(&resolve, "resolve", "address of host in form host:port:addr[,addr] in addition to server overrides, can be repeated")
+	dnsServer := fs.String("dns-server", "", "IP address of DNS server with optional port instead of server one")
+	var headers headerFlags
+	query := queryFlags{}
+	fs.Var(&headers, "H", "HTTP header 'Name: value' of each request, can be repeated")
+	fs.Var(query, "query", "query parameter 'name=value' of each request, can be repeated")
+	method := fs.String("method", "", "HTTP method of requests (GET if empty)")
+	rangeStart := fs.Uint64("range-start", 0, "offset of continuous HTTP stream requested by Range header")
+	var capture sizeFlag
+	fs.Var(&capture, "capture", "size of sample of each session saved to server records directory (0 disables)")
+	fs.Var(&playoutBitrate, "playout-bitrate", "nominal bitrate for playout simulation (bps, taken from HLS/DASH if not set)")
+	fs.Var(&rateLimit, "rate-limit", "limit of reading rate (bps, suffix k, M or G allowed), 0 disables server limit")
+	admission := addAdmissionFlags(fs)
+	retry := addRetryFlags(fs)
+
+	if err := c.parseClientFlags(fs, args); err != nil {
+		return err
+	}
+
+	if admission.isSet(fs) {
+		if _, err := admission.policy(); err != nil {
+			return err
+		}
+		c.taskAdmission = admission.proto()
+	}
+
+	if _, err := hls.ParseVariantPolicy(*variant); err != nil {
+		return err
+	}
+
+	c.taskOptions = &downloader.TaskOptions{
+		Variant:        *variant,
+		Type:           *streamType,
+		PlayoutBitrate: float64(playoutBitrate),
+		Headers:        headers,
+		Method:         *method,
+		RangeStart:     *rangeStart,
+		Query:          query,
+		Sources:        sources,
+		Resolve:        resolve,
+		DnsServer:      *dnsServer,
+	}
+	if isFlagSet(fs, "analyze") {
+		c.taskOptions.Analyze = analyze
+	}
+	if isFlagSet(fs, "playout") {
+		c.taskOptions.Playout = playout
+	}
+	if isFlagSet(fs, "record") {
+		c.taskOptions.Record = record
+	}
+	if isFlagSet(fs, "verify") {
+		c.taskOptions.Verify = verify
+	}
+	if isFlagSet(fs, "isolated-transport") {
+		c.taskOptions.IsolatedTransport = isolatedTransport
+	}
+	if isFlagSet(fs, "capture") {
+		size := uint64(capture)
+		c.taskOptions.Capture = &size
+	}
+	if isFlagSet(fs, "rate-limit") {
+		limit := float64(rateLimit)
+		c.taskOptions.RateLimit = &limit
+	}
+	if retry.isSet(fs) {
+		policy, err := retry.proto(fs)
+		if err != nil {
+			return err
+		}
+		c.taskOptions.Retry = policy
+	}
+
+	return nil
+}
+
+func (c *commandLineArgs) parseTopArgs(args []string) error {
+	fs := flag.NewFlagSet("top", flag.ContinueOnError)
+	fs.DurationVar(&c.topInterval, "interval", time.Second, "refresh interval")
+	fs.StringVar(&c.topSort, "sort", "bitrate", "order of tasks: bitrate, status or id")
+
+	if err := c.parseClientFlags(fs, args); err != nil {
+		return err
+	}
+
+	if _, ok := topSortOrders[c.topSort]; !ok {
+		return fmt.Errorf("unsupported sort order: %s", c.topSort)
+	}
 	return nil
 }
 
+func (c *commandLineArgs) parseEventsArgs(args []string) error {
+	fs := flag.NewFlagSet("events", flag.ContinueOnError)
+	ids := fs.String("task", "", "comma-separated IDs of tasks to watch (all if empty)")
+
+	if err := c.parseClientFlags(fs, args); err != nil {
+		return err
+	}
+
+	var err error
+	c.eventTaskIDs, err = parseTaskIDs(*ids)
+	return err
+}
+
 func (c *commandLineArgs) parseClientArgs(args []string) error {
-	fs := flag.NewFlagSet("client", flag.ContinueOnError)
+	return c.parseClientFlags(flag.NewFlagSet("client", flag.ContinueOnError), args)
+}
+
+func (c *commandLineArgs) parseClientFlags(fs *flag.FlagSet, args []string) error {
 	endpoint := fs.String("endpoint", defaultEndpoint, "endpoint of server")
 
 	err := fs.Parse(args)
@@ -191,11 +581,34 @@ func parseEndpoint(endpoint string) (schema, addr string, err error) {
 }
 
 func printUsage() {
-	fmt.Println("Usage:\t./downloader server|task|status|stop|done [-endpoint <endpoint>")
-	fmt.Println("Run server:\t\t./downloader server [-timeout N] [-endpoint <endpoint>]")
+	fmt.Println("Usage:\t./downloader server|task|maintain|run|status|list|top|events|stop|done [-endpoint <endpoint>")
+	fmt.Println("Run server:\t\t./downloader server [-timeout N] [-endpoint <endpoint>] [-metrics <addr>]")
 	fmt.Println("\t\t\t\t-timeout=N read timeout for HTTP stream (sec)")
-	fmt.Println("Add download task:\t./downloader task <URL> [-endpoint <endpoint>]")
+	fmt.Println("\t\t\t\t-metrics=<addr> expose Prometheus metrics on http://<addr>/metrics")
+	fmt.Println("\t\t\t\t-variant=highest|lowest|<bps> HLS variant stream")
+	fmt.Println("\t\t\t\t-analyze check MPEG-TS continuity and PCR of received media")
+	fmt.Println("\t\t\t\t-playout [-playout-bitrate BPS] simulate player buffer and count rebuffers")
+	fmt.Println("\t\t\t\t-record-dir <dir> [-record] [-record-file-size N] [-record-file-duration D] [-record-max-size N] write received media to files")
+	fmt.Println("\t\t\t\t-verify [-verify-bytes N] [-capture N] compare content hashes of tasks, capture samples of sessions")
+	fmt.Println("\t\t\t\t[-tls-insecure] [-tls-ca F] [-tls-cert F -tls-key F] [-tls-server-name N] [-tls-min-version V] TLS of HTTPS streams")
+	fmt.Println("\t\t\t\t[-http-version http1|http2] [-max-conns-per-host N] [-max-idle-conns-per-host N] [-idle-timeout D] [-dial-timeout D] [-disable-keep-alive] HTTP connections")
+	fmt.Println("\t\t\t\t[-source ADDR|IFACE]... bind outgoing connections to local addresses in round-robin manner")
+	fmt.Println("\t\t\t\t[-resolve HOST:PORT:ADDR]... [-dns-server ADDR] override addresses of hosts, resolve by DNS server")
+	fmt.Println("\t\t\t\t[-isolated-transport] own HTTP transport of each task instead of shared connection pool")
+	fmt.Println("\t\t\t\t[-rate-limit BPS] [-global-rate-limit BPS] limit reading rate of each task and of all tasks")
+	fmt.Println("\t\t\t\t[-admission-rate R] [-admission-jitter F] limit rate of starting tasks")
+	fmt.Println("\t\t\t\t[-retries N] [-backoff D] [-max-backoff D] [-jitter F] [-retry-on CODES] reconnect policy")
+	fmt.Println("Add download tasks:\t./downloader task <URL>... [-endpoint <endpoint>] [-type T] [-variant V] [-analyze] [-playout] [-playout-bitrate BPS] [-record] [-verify] [-capture N] [-rate-limit BPS] [-isolated-transport] [-source ADDR|IFACE]... [-resolve HOST:PORT:ADDR]... [-dns-server ADDR] [request options] [admission] [reconnect policy]")
+	fmt.Println("Maintain tasks count:\t./downloader maintain <N> <URL>... [-endpoint <endpoint>] [-id ID] [-random] [task options] [admission] [reconnect policy]")
+	fmt.Println("Stop maintaining:\t./downloader maintain stop <ID> [-endpoint <endpoint>]")
+	fmt.Println("\t\t\t\trequest options: [-H 'Name: value']... [-query name=value]... [-method M] [-range-start N]")
+	fmt.Println("\t\t\t\ttemplates {{id}}, {{seq}}, {{time}}, {{rand}}, {{uuid}} are substituted in header and query values")
+	fmt.Println("Run load scenario:\t./downloader run <scenario.yaml> [-endpoint <endpoint>]")
+	fmt.Println("Control task:\t\t./downloader task stop|restart|rm <ID> [-endpoint <endpoint>]")
 	fmt.Println("Print server statistic:\t./downloader status [-endpoint <endpoint>]")
+	fmt.Println("List tasks:\t\t./downloader list [-endpoint <endpoint>]")
+	fmt.Println("Tail task events:\t./downloader events [-endpoint <endpoint>] [-task ID,...]")
+	fmt.Println("Watch tasks:\t\t./downloader top [-endpoint <endpoint>] [-interval D] [-sort bitrate|status|id]")
 	fmt.Println("Stop all tasks:\t\t./downloader stop [-endpoint <endpoint>]")
 	fmt.Println("Teardown server:\t./downloader done [-endpoint <endpoint>]")
 
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/racoon-devel/downloader/internal/api/downloader"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const eventTimeLayout = "2006-01-02T15:04:05.000Z07:00"
+
+// runEvents prints task events until the context is cancelled. Each event is printed on a separate line:
+// <time> <task ID> <type> [<reason>] [<message>]
+func runEvents(ctx context.Context, client downloader.DownloaderClient, ids []uint64) error {
+	stream, err := client.WatchEvents(ctx, &downloader.WatchEventsRequest{TaskIds: ids})
+	if err != nil {
+		return err
+	}
+
+	for {
+		e, err := stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) || status.Code(err) == codes.Canceled {
+				return nil
+			}
+			return err
+		}
+		printEvent(os.Stdout, e)
+	}
+}
+
+func printEvent(w io.Writer, e *downloader.Event) {
+	line := fmt.Sprintf("%s %d %s", e.Time.AsTime().Local().Format(eventTimeLayout), e.TaskId,
+		strings.ToLower(e.Type.String()))
+	if e.Reason != "" {
+		line += " " + e.Reason
+	}
+	if e.Message != "" {
+		line += " " + strconv.Quote(e.Message)
+	}
+	_, _ = fmt.Fprintln(w, line)
+}
+
+func parseTaskIDs(list string) ([]uint64, error) {
+	if list == "" {
+		return nil, nil
+	}
+	var ids []uint64
+	for _, s := range strings.Split(list, ",") {
+		id, err := strconv.ParseUint(strings.TrimSpace(s), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid task ID: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
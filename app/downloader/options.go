@@ -0,0 +1,252 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/racoon-devel/downloader/internal/api/downloader"
+	"github.com/racoon-devel/downloader/internal/server"
+	"github.com/racoon-devel/downloader/internal/task"
+	"github.com/racoon-devel/downloader/internal/utils"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+const defaultBackoff = 1 * time.Second
+const defaultMaxBackoff = 30 * time.Second
+
+type retryFlags struct {
+	maxAttempts *int
+	backoff     *time.Duration
+	maxBackoff  *time.Duration
+	jitter      *float64
+	retryOn     *string
+}
+
+func addRetryFlags(fs *flag.FlagSet) *retryFlags {
+	return &retryFlags{
+		maxAttempts: fs.Int("retries", 0, "max reconnect attempts in a row (-1 - unlimited)"),
+		backoff:     fs.Duration("backoff", defaultBackoff, "initial delay before reconnect"),
+		maxBackoff:  fs.Duration("max-backoff", defaultMaxBackoff, "max delay before reconnect"),
+		jitter:      fs.Float64("jitter", 0, "random deviation of reconnect delay [0..1]"),
+		retryOn:     fs.String("retry-on", "", "comma-separated HTTP status codes to reconnect on (any if empty)"),
+	}
+}
+
+// isSet checks whether any of retry flags is presented in command line
+func (f *retryFlags) isSet(fs *flag.FlagSet) bool {
+	return isFlagSet(fs, "retries", "backoff", "max-backoff", "jitter", "retry-on")
+}
+
+// isFlagSet checks whether any of the flags is presented in command line
+func isFlagSet(fs *flag.FlagSet, names ...string) bool {
+	set := false
+	fs.Visit(func(fl *flag.Flag) {
+		for _, name := range names {
+			if fl.Name == name {
+				set = true
+			}
+		}
+	})
+	return set
+}
+
+func (f *retryFlags) policy() (task.RetryPolicy, error) {
+	codes, err := parseStatusCodes(*f.retryOn)
+	if err != nil {
+		return task.RetryPolicy{}, err
+	}
+	return task.RetryPolicy{
+		MaxAttempts:    *f.maxAttempts,
+		InitialBackoff: *f.backoff,
+		MaxBackoff:     *f.maxBackoff,
+		Jitter:         *f.jitter,
+		RetryOnStatus:  codes,
+	}, nil
+}
+
+// proto makes task retry policy of the flags presented in command line, the rest is taken from server policy
+func (f *retryFlags) proto(fs *flag.FlagSet) (*downloader.RetryPolicy, error) {
+	policy, err := f.policy()
+	if err != nil {
+		return nil, err
+	}
+	p := &downloader.RetryPolicy{}
+	if isFlagSet(fs, "retries") {
+		attempts := int32(policy.MaxAttempts)
+		p.MaxAttempts = &attempts
+	}
+	if isFlagSet(fs, "backoff") {
+		p.InitialBackoff = durationpb.New(policy.InitialBackoff)
+	}
+	if isFlagSet(fs, "max-backoff") {
+		p.MaxBackoff = durationpb.New(policy.MaxBackoff)
+	}
+	if isFlagSet(fs, "jitter") {
+		p.Jitter = &policy.Jitter
+	}
+	if isFlagSet(fs, "retry-on") {
+		p.RetryOnStatus = &downloader.StatusCodes{}
+		for _, code := range policy.RetryOnStatus {
+			p.RetryOnStatus.Codes = append(p.RetryOnStatus.Codes, uint32(code))
+		}
+	}
+	return p, nil
+}
+
+func parseStatusCodes(list string) ([]int, error) {
+	var codes []int
+	for _, s := range strings.Split(list, ",") {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		code, err := strconv.Atoi(s)
+		if err != nil || code < 100 || code > 999 {
+			return nil, fmt.Errorf("invalid HTTP status code: %s", s)
+		}
+		codes = append(codes, code)
+	}
+	return codes, nil
+}
+
+type admissionFlags struct {
+	rate   *float64
+	jitter *float64
+}
+
+func addAdmissionFlags(fs *flag.FlagSet) *admissionFlags {
+	return &admissionFlags{
+		rate:   fs.Float64("admission-rate", 0, "max tasks started per second (unlimited if zero)"),
+		jitter: fs.Float64("admission-jitter", 0, "random deviation of intervals between task starts [0..1]"),
+	}
+}
+
+// isSet checks whether any of admission flags is presented in command line
+func (f *admissionFlags) isSet(fs *flag.FlagSet) bool {
+	return isFlagSet(fs, "admission-rate", "admission-jitter")
+}
+
+func (f *admissionFlags) policy() (server.AdmissionPolicy, error) {
+	if *f.rate < 0 {
+		return server.AdmissionPolicy{}, errors.New("admission rate must not be negative")
+	}
+	if *f.jitter < 0 || *f.jitter > 1 {
+		return server.AdmissionPolicy{}, errors.New("admission jitter must be in range [0..1]")
+	}
+	return server.AdmissionPolicy{Rate: *f.rate, Jitter: *f.jitter}, nil
+}
+
+func (f *admissionFlags) proto() *downloader.AdmissionPolicy {
+	return &downloader.AdmissionPolicy{Rate: *f.rate, Jitter: *f.jitter}
+}
+
+// bitrateFlag is bits per second which can be set with suffix k, M or G, e.g. 4M
+type bitrateFlag float64
+
+func (f *bitrateFlag) String() string {
+	return strconv.FormatFloat(float64(*f), 'f', -1, 64)
+}
+
+func (f *bitrateFlag) Set(value string) error {
+	bps, err := utils.ParseBitrate(value)
+	if err != nil {
+		return err
+	}
+	*f = bitrateFlag(bps)
+	return nil
+}
+
+// sizeFlag is bytes count which can be set with suffix K, M or G, e.g. 100M
+type sizeFlag uint64
+
+func (f *sizeFlag) String() string {
+	return strconv.FormatUint(uint64(*f), 10)
+}
+
+func (f *sizeFlag) Set(value string) error {
+	bytes, err := utils.ParseBytes(value)
+	if err != nil {
+		return err
+	}
+	*f = sizeFlag(bytes)
+	return nil
+}
+
+// headerFlags are repeated HTTP headers in form "Name: value"
+type headerFlags []*downloader.Header
+
+func (f *headerFlags) String() string {
+	return fmt.Sprint(len(*f), " headers")
+}
+
+func (f *headerFlags) Set(value string) error {
+	name, val, ok := strings.Cut(value, ":")
+	if !ok || strings.TrimSpace(name) == "" {
+		return fmt.Errorf("header must be in form 'Name: value': %s", value)
+	}
+	*f = append(*f, &downloader.Header{Name: strings.TrimSpace(name), Value: strings.TrimSpace(val)})
+	return nil
+}
+
+// sourceFlags are repeated local addresses or interfaces, each value may be a comma-separated list
+type sourceFlags []string
+
+func (f *sourceFlags) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *sourceFlags) Set(value string) error {
+	for _, source := range strings.Split(value, ",") {
+		if source = strings.TrimSpace(source); source != "" {
+			*f = append(*f, source)
+		}
+	}
+	return nil
+}
+
+// resolveFlags are repeated host overrides in curl --resolve format host:port:addr[,addr]
+type resolveFlags []string
+
+func (f *resolveFlags) String() string {
+	return strings.Join(*f, " ")
+}
+
+func (f *resolveFlags) Set(value string) error {
+	if err := (task.HostOverrides{}).Add(value); err != nil {
+		return err
+	}
+	*f = append(*f, value)
+	return nil
+}
+
+// hosts returns parsed overrides, nil if flag is not set
+func (f resolveFlags) hosts() task.HostOverrides {
+	if len(f) == 0 {
+		return nil
+	}
+	hosts := task.HostOverrides{}
+	for _, entry := range f {
+		_ = hosts.Add(entry)
+	}
+	return hosts
+}
+
+// queryFlags are repeated query parameters in form "name=value"
+type queryFlags map[string]string
+
+func (f queryFlags) String() string {
+	return fmt.Sprint(len(f), " parameters")
+}
+
+func (f queryFlags) Set(value string) error {
+	name, val, ok := strings.Cut(value, "=")
+	if !ok || name == "" {
+		return fmt.Errorf("query parameter must be in form 'name=value': %s", value)
+	}
+	f[name] = val
+	return nil
+}
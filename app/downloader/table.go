@@ -0,0 +1,141 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/racoon-devel/downloader/internal/api/downloader"
+	"github.com/racoon-devel/downloader/internal/utils"
+)
+
+const timeLayout = "2006-01-02 15:04:05"
+
+func printTasks(w io.Writer, tasks []*downloader.TaskInfo) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "ID\tURL\tTYPE\tSOURCE\tSTATUS\tSTARTED\tRECEIVED\tBITRATE\tTTFB\tRECONNECTS\tSEGMENTS\tSTALLS\tPACKETS\tTS\tPLAYOUT\tCONTENT\tLAST ERROR")
+	for _, t := range tasks {
+		started := "-"
+		if t.StartTime != nil {
+			started = t.StartTime.AsTime().Local().Format(timeLayout)
+		}
+		ttfb := "-"
+		if t.Ttfb != nil {
+			ttfb = t.Ttfb.AsDuration().Round(time.Millisecond).String()
+		}
+		if t.DnsLookup != nil {
+			ttfb += " (dns " + t.DnsLookup.AsDuration().Round(time.Millisecond).String() + ")"
+		}
+		segments := "-"
+		if t.Segments != 0 {
+			segments = fmt.Sprintf("%d (%s)", t.Segments, t.SegmentLatency.AsDuration().Round(time.Millisecond))
+		}
+		packets := "-"
+		if t.Packets != 0 {
+			packets = fmt.Sprintf("%d (%.0f pps, lost %d", t.Packets, t.PacketRate, t.PacketsLost)
+			if t.ReorderedPackets != 0 {
+				packets += fmt.Sprintf(", reordered %d", t.ReorderedPackets)
+			}
+			if t.DuplicatePackets != 0 {
+				packets += fmt.Sprintf(", duplicates %d", t.DuplicatePackets)
+			}
+			if t.MalformedPackets != 0 {
+				packets += fmt.Sprintf(", malformed %d", t.MalformedPackets)
+			}
+			packets += ")"
+		}
+		received := utils.FormatBytes(t.BytesReceived)
+		if t.RecordedBytes != 0 {
+			received += " (recorded " + utils.FormatBytes(t.RecordedBytes) + ")"
+		}
+		analysis := "-"
+		if t.Analysis != nil {
+			analysis = fmt.Sprintf("cc %d, sync %d, jitter %s", t.Analysis.ContinuityErrors, t.Analysis.SyncLosses,
+				t.Analysis.PcrJitterMax.AsDuration().Round(time.Microsecond))
+		}
+		source := "-"
+		if t.Source != "" {
+			source = t.Source
+		}
+		content := "-"
+		if t.Verified != 0 || t.Mismatches != 0 {
+			content = fmt.Sprintf("ok %d, mismatch %d", t.Verified, t.Mismatches)
+		}
+		_, _ = fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%d\t%s\t%d\t%s\t%s\t%s\t%s\t%s\n", t.Id, t.Url, t.Type, source, t.Status, started,
+			received, utils.FormatBitrate(t.Bitrate), ttfb, t.Reconnects, segments, t.Stalls,
+			packets, analysis, formatPlayout(t.Playout), content, formatLastError(t))
+	}
+	_ = tw.Flush()
+}
+
+// formatPlayout describes state of simulated player: buffer level, startup delay and rebuffers
+func formatPlayout(p *downloader.PlayoutStats) string {
+	switch {
+	case p == nil:
+		return "-"
+	case p.Bitrate == 0:
+		return "unknown bitrate"
+	case p.StartupDelay.AsDuration() == 0:
+		return fmt.Sprintf("buffering %s", p.BufferLevel.AsDuration().Round(100*time.Millisecond))
+	}
+	state := "playing"
+	if !p.Playing {
+		state = "rebuffering"
+	}
+	return fmt.Sprintf("%s, buffer %s, startup %s, rebuffers %d (%s)", state,
+		p.BufferLevel.AsDuration().Round(100*time.Millisecond), p.StartupDelay.AsDuration().Round(time.Millisecond),
+		p.Rebuffers, p.RebufferTime.AsDuration().Round(100*time.Millisecond))
+}
+
+// formatLastError prefixes the last error with its failure reason
+func formatLastError(t *downloader.TaskInfo) string {
+	switch {
+	case t.LastError == "":
+		return "-"
+	case t.FailureReason == "":
+		return t.LastError
+	default:
+		return "[" + t.FailureReason + "] " + t.LastError
+	}
+}
+
+func printTargets(w io.Writer, targets []*downloader.TargetInfo) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, tg := range targets {
+		_, _ = fmt.Fprintf(tw, "Target %d:\ttasks %d/%d\treplaced %d\tURLs %d\n", tg.Id, tg.Actual, tg.Count,
+			tg.Replaced, tg.Urls)
+	}
+	_ = tw.Flush()
+}
+
+func printSources(w io.Writer, sources []*downloader.SourceStats) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, s := range sources {
+		_, _ = fmt.Fprintf(tw, "Source %s:	tasks %d	active %d	%s	received %s	failures %d\n", s.Address, s.Tasks,
+			s.Active, utils.FormatBitrate(s.Bitrate), utils.FormatBytes(s.TotalBytes), s.Failures)
+	}
+	_ = tw.Flush()
+}
+
+func printAnalysis(w io.Writer, analysis map[uint64]*downloader.TSStats) {
+	ids := make([]uint64, 0, len(analysis))
+	for id := range analysis {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, id := range ids {
+		a := analysis[id]
+		_, _ = fmt.Fprintf(tw, "Task %d:\tpackets %d\tsync losses %d\tcc errors %d\tPCR jitter max %s avg %s\n", id, a.Packets,
+			a.SyncLosses, a.ContinuityErrors, a.PcrJitterMax.AsDuration().Round(time.Microsecond),
+			a.PcrJitterAvg.AsDuration().Round(time.Microsecond))
+		for _, pid := range a.Pids {
+			_, _ = fmt.Fprintf(tw, "\tPID 0x%04x\tpackets %d\tcc errors %d\t%s\n", pid.Pid, pid.Packets,
+				pid.ContinuityErrors, utils.FormatBitrate(pid.Bitrate))
+		}
+	}
+	_ = tw.Flush()
+}
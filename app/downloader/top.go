@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/racoon-devel/downloader/internal/api/downloader"
+	"github.com/racoon-devel/downloader/internal/server"
+	"github.com/racoon-devel/downloader/internal/utils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+const clearScreen = "\033[H\033[2J"
+
+// topSortOrders are supported orders of tasks in top view
+var topSortOrders = map[string]func(a, b *downloader.TaskInfo) bool{
+	"bitrate": func(a, b *downloader.TaskInfo) bool {
+		return a.Bitrate > b.Bitrate
+	},
+	"status": func(a, b *downloader.TaskInfo) bool {
+		return a.Status < b.Status
+	},
+	"id": func(a, b *downloader.TaskInfo) bool {
+		return a.Id < b.Id
+	},
+}
+
+// runTop renders continuously refreshing table of tasks until the context is cancelled
+func runTop(ctx context.Context, client downloader.DownloaderClient, interval time.Duration, sortBy string) error {
+	stream, err := client.WatchStatus(ctx, &downloader.WatchStatusRequest{Interval: durationpb.New(interval)})
+	if err != nil {
+		return err
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) || status.Code(err) == codes.Canceled {
+				return nil
+			}
+			return err
+		}
+		_, _ = fmt.Fprint(os.Stdout, clearScreen)
+		printTop(os.Stdout, resp, sortBy)
+	}
+}
+
+func printTop(w io.Writer, resp *downloader.WatchStatusResponse, sortBy string) {
+	_, _ = fmt.Fprintf(w, "%s  %s\n", resp.Time.AsTime().Local().Format(timeLayout), server.StatDictionary(resp.Status.Stat))
+	_, _ = fmt.Fprintf(w, "Rate: %s, received: %s, tasks: %d\n\n", utils.FormatBitrate(resp.Status.BytesPerSec*8),
+		utils.FormatBytes(resp.Status.TotalBytes), len(resp.Tasks))
+
+	less := topSortOrders[sortBy]
+	sort.SliceStable(resp.Tasks, func(i, j int) bool {
+		if less(resp.Tasks[i], resp.Tasks[j]) {
+			return true
+		}
+		if less(resp.Tasks[j], resp.Tasks[i]) {
+			return false
+		}
+		return resp.Tasks[i].Id < resp.Tasks[j].Id
+	})
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "ID\tSTATUS\tBITRATE\tRECEIVED\tRECONNECTS\tSTALLS\tURL\tLAST ERROR")
+	for _, t := range resp.Tasks {
+		_, _ = fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%d\t%d\t%s\t%s\n", t.Id, t.Status, utils.FormatBitrate(t.Bitrate),
+			utils.FormatBytes(t.BytesReceived), t.Reconnects, t.Stalls, t.Url, formatLastError(t))
+	}
+	_ = tw.Flush()
+}
@@ -23,9 +23,17 @@ const _ = grpc.SupportPackageIsVersion7
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type DownloaderClient interface {
-	AddTask(ctx context.Context, in *AddTaskRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
-	AddTasks(ctx context.Context, in *AddTasksRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	AddTask(ctx context.Context, in *AddTaskRequest, opts ...grpc.CallOption) (*AddTaskResponse, error)
+	AddTasks(ctx context.Context, in *AddTasksRequest, opts ...grpc.CallOption) (*AddTasksResponse, error)
 	Status(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*StatusResponse, error)
+	WatchStatus(ctx context.Context, in *WatchStatusRequest, opts ...grpc.CallOption) (Downloader_WatchStatusClient, error)
+	WatchEvents(ctx context.Context, in *WatchEventsRequest, opts ...grpc.CallOption) (Downloader_WatchEventsClient, error)
+	ListTasks(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListTasksResponse, error)
+	StopTask(ctx context.Context, in *TaskRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RestartTask(ctx context.Context, in *TaskRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RemoveTask(ctx context.Context, in *TaskRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	Maintain(ctx context.Context, in *MaintainRequest, opts ...grpc.CallOption) (*MaintainResponse, error)
+	StopMaintain(ctx context.Context, in *TargetRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	Stop(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	Done(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error)
 }
@@ -38,8 +46,8 @@ func NewDownloaderClient(cc grpc.ClientConnInterface) DownloaderClient {
 	return &downloaderClient{cc}
 }
 
-func (c *downloaderClient) AddTask(ctx context.Context, in *AddTaskRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
-	out := new(emptypb.Empty)
+func (c *downloaderClient) AddTask(ctx context.Context, in *AddTaskRequest, opts ...grpc.CallOption) (*AddTaskResponse, error) {
+	out := new(AddTaskResponse)
 	err := c.cc.Invoke(ctx, "/Downloader/AddTask", in, out, opts...)
 	if err != nil {
 		return nil, err
@@ -47,8 +55,8 @@ func (c *downloaderClient) AddTask(ctx context.Context, in *AddTaskRequest, opts
 	return out, nil
 }
 
-func (c *downloaderClient) AddTasks(ctx context.Context, in *AddTasksRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
-	out := new(emptypb.Empty)
+func (c *downloaderClient) AddTasks(ctx context.Context, in *AddTasksRequest, opts ...grpc.CallOption) (*AddTasksResponse, error) {
+	out := new(AddTasksResponse)
 	err := c.cc.Invoke(ctx, "/Downloader/AddTasks", in, out, opts...)
 	if err != nil {
 		return nil, err
@@ -65,6 +73,124 @@ func (c *downloaderClient) Status(ctx context.Context, in *emptypb.Empty, opts .
 	return out, nil
 }
 
+func (c *downloaderClient) WatchStatus(ctx context.Context, in *WatchStatusRequest, opts ...grpc.CallOption) (Downloader_WatchStatusClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Downloader_ServiceDesc.Streams[0], "/Downloader/WatchStatus", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &downloaderWatchStatusClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Downloader_WatchStatusClient interface {
+	Recv() (*WatchStatusResponse, error)
+	grpc.ClientStream
+}
+
+type downloaderWatchStatusClient struct {
+	grpc.ClientStream
+}
+
+func (x *downloaderWatchStatusClient) Recv() (*WatchStatusResponse, error) {
+	m := new(WatchStatusResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *downloaderClient) WatchEvents(ctx context.Context, in *WatchEventsRequest, opts ...grpc.CallOption) (Downloader_WatchEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Downloader_ServiceDesc.Streams[1], "/Downloader/WatchEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &downloaderWatchEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Downloader_WatchEventsClient interface {
+	Recv() (*Event, error)
+	grpc.ClientStream
+}
+
+type downloaderWatchEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *downloaderWatchEventsClient) Recv() (*Event, error) {
+	m := new(Event)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *downloaderClient) ListTasks(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListTasksResponse, error) {
+	out := new(ListTasksResponse)
+	err := c.cc.Invoke(ctx, "/Downloader/ListTasks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *downloaderClient) StopTask(ctx context.Context, in *TaskRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/Downloader/StopTask", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *downloaderClient) RestartTask(ctx context.Context, in *TaskRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/Downloader/RestartTask", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *downloaderClient) RemoveTask(ctx context.Context, in *TaskRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/Downloader/RemoveTask", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *downloaderClient) Maintain(ctx context.Context, in *MaintainRequest, opts ...grpc.CallOption) (*MaintainResponse, error) {
+	out := new(MaintainResponse)
+	err := c.cc.Invoke(ctx, "/Downloader/Maintain", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *downloaderClient) StopMaintain(ctx context.Context, in *TargetRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/Downloader/StopMaintain", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *downloaderClient) Stop(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	out := new(emptypb.Empty)
 	err := c.cc.Invoke(ctx, "/Downloader/Stop", in, out, opts...)
@@ -87,9 +213,17 @@ func (c *downloaderClient) Done(ctx context.Context, in *emptypb.Empty, opts ...
 // All implementations must embed UnimplementedDownloaderServer
 // for forward compatibility
 type DownloaderServer interface {
-	AddTask(context.Context, *AddTaskRequest) (*emptypb.Empty, error)
-	AddTasks(context.Context, *AddTasksRequest) (*emptypb.Empty, error)
+	AddTask(context.Context, *AddTaskRequest) (*AddTaskResponse, error)
+	AddTasks(context.Context, *AddTasksRequest) (*AddTasksResponse, error)
 	Status(context.Context, *emptypb.Empty) (*StatusResponse, error)
+	WatchStatus(*WatchStatusRequest, Downloader_WatchStatusServer) error
+	WatchEvents(*WatchEventsRequest, Downloader_WatchEventsServer) error
+	ListTasks(context.Context, *emptypb.Empty) (*ListTasksResponse, error)
+	StopTask(context.Context, *TaskRequest) (*emptypb.Empty, error)
+	RestartTask(context.Context, *TaskRequest) (*emptypb.Empty, error)
+	RemoveTask(context.Context, *TaskRequest) (*emptypb.Empty, error)
+	Maintain(context.Context, *MaintainRequest) (*MaintainResponse, error)
+	StopMaintain(context.Context, *TargetRequest) (*emptypb.Empty, error)
 	Stop(context.Context, *emptypb.Empty) (*emptypb.Empty, error)
 	Done(context.Context, *emptypb.Empty) (*emptypb.Empty, error)
 	mustEmbedUnimplementedDownloaderServer()
@@ -99,15 +233,39 @@ type DownloaderServer interface {
 type UnimplementedDownloaderServer struct {
 }
 
-func (UnimplementedDownloaderServer) AddTask(context.Context, *AddTaskRequest) (*emptypb.Empty, error) {
+func (UnimplementedDownloaderServer) AddTask(context.Context, *AddTaskRequest) (*AddTaskResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddTask not implemented")
 }
-func (UnimplementedDownloaderServer) AddTasks(context.Context, *AddTasksRequest) (*emptypb.Empty, error) {
+func (UnimplementedDownloaderServer) AddTasks(context.Context, *AddTasksRequest) (*AddTasksResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddTasks not implemented")
 }
 func (UnimplementedDownloaderServer) Status(context.Context, *emptypb.Empty) (*StatusResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Status not implemented")
 }
+func (UnimplementedDownloaderServer) WatchStatus(*WatchStatusRequest, Downloader_WatchStatusServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchStatus not implemented")
+}
+func (UnimplementedDownloaderServer) WatchEvents(*WatchEventsRequest, Downloader_WatchEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchEvents not implemented")
+}
+func (UnimplementedDownloaderServer) ListTasks(context.Context, *emptypb.Empty) (*ListTasksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTasks not implemented")
+}
+func (UnimplementedDownloaderServer) StopTask(context.Context, *TaskRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StopTask not implemented")
+}
+func (UnimplementedDownloaderServer) RestartTask(context.Context, *TaskRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestartTask not implemented")
+}
+func (UnimplementedDownloaderServer) RemoveTask(context.Context, *TaskRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveTask not implemented")
+}
+func (UnimplementedDownloaderServer) Maintain(context.Context, *MaintainRequest) (*MaintainResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Maintain not implemented")
+}
+func (UnimplementedDownloaderServer) StopMaintain(context.Context, *TargetRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StopMaintain not implemented")
+}
 func (UnimplementedDownloaderServer) Stop(context.Context, *emptypb.Empty) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Stop not implemented")
 }
@@ -181,6 +339,156 @@ func _Downloader_Status_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Downloader_WatchStatus_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchStatusRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DownloaderServer).WatchStatus(m, &downloaderWatchStatusServer{stream})
+}
+
+type Downloader_WatchStatusServer interface {
+	Send(*WatchStatusResponse) error
+	grpc.ServerStream
+}
+
+type downloaderWatchStatusServer struct {
+	grpc.ServerStream
+}
+
+func (x *downloaderWatchStatusServer) Send(m *WatchStatusResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Downloader_WatchEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DownloaderServer).WatchEvents(m, &downloaderWatchEventsServer{stream})
+}
+
+type Downloader_WatchEventsServer interface {
+	Send(*Event) error
+	grpc.ServerStream
+}
+
+type downloaderWatchEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *downloaderWatchEventsServer) Send(m *Event) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Downloader_ListTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DownloaderServer).ListTasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Downloader/ListTasks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DownloaderServer).ListTasks(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Downloader_StopTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DownloaderServer).StopTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Downloader/StopTask",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DownloaderServer).StopTask(ctx, req.(*TaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Downloader_RestartTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DownloaderServer).RestartTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Downloader/RestartTask",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DownloaderServer).RestartTask(ctx, req.(*TaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Downloader_RemoveTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DownloaderServer).RemoveTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Downloader/RemoveTask",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DownloaderServer).RemoveTask(ctx, req.(*TaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Downloader_Maintain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MaintainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DownloaderServer).Maintain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Downloader/Maintain",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DownloaderServer).Maintain(ctx, req.(*MaintainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Downloader_StopMaintain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TargetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DownloaderServer).StopMaintain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/Downloader/StopMaintain",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DownloaderServer).StopMaintain(ctx, req.(*TargetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Downloader_Stop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
@@ -236,6 +544,30 @@ var Downloader_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Status",
 			Handler:    _Downloader_Status_Handler,
 		},
+		{
+			MethodName: "ListTasks",
+			Handler:    _Downloader_ListTasks_Handler,
+		},
+		{
+			MethodName: "StopTask",
+			Handler:    _Downloader_StopTask_Handler,
+		},
+		{
+			MethodName: "RestartTask",
+			Handler:    _Downloader_RestartTask_Handler,
+		},
+		{
+			MethodName: "RemoveTask",
+			Handler:    _Downloader_RemoveTask_Handler,
+		},
+		{
+			MethodName: "Maintain",
+			Handler:    _Downloader_Maintain_Handler,
+		},
+		{
+			MethodName: "StopMaintain",
+			Handler:    _Downloader_StopMaintain_Handler,
+		},
 		{
 			MethodName: "Stop",
 			Handler:    _Downloader_Stop_Handler,
@@ -245,6 +577,17 @@ var Downloader_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _Downloader_Done_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchStatus",
+			Handler:       _Downloader_WatchStatus_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchEvents",
+			Handler:       _Downloader_WatchEvents_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "downloader.proto",
 }
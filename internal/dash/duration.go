@@ -0,0 +1,45 @@
+package dash
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+var durationRegexp = regexp.MustCompile(`^P(?:(\d+(?:\.\d+)?)Y)?(?:(\d+(?:\.\d+)?)M)?(?:(\d+(?:\.\d+)?)D)?(?:T(?:(\d+(?:\.\d+)?)H)?(?:(\d+(?:\.\d+)?)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+
+// units of ISO 8601 duration components. Years and months are approximated
+var durationUnits = []time.Duration{
+	365 * 24 * time.Hour,
+	30 * 24 * time.Hour,
+	24 * time.Hour,
+	time.Hour,
+	time.Minute,
+	time.Second,
+}
+
+// parseDuration parses ISO 8601 duration like PT1H2M3.5S. Empty string means zero duration
+func parseDuration(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+
+	match := durationRegexp.FindStringSubmatch(s)
+	if match == nil || s == "P" || s[len(s)-1] == 'T' {
+		return 0, fmt.Errorf("invalid duration: %s", s)
+	}
+
+	var d time.Duration
+	for i, unit := range durationUnits {
+		if match[i+1] == "" {
+			continue
+		}
+		v, err := strconv.ParseFloat(match[i+1], 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration: %s", s)
+		}
+		d += time.Duration(v * float64(unit))
+	}
+	return d, nil
+}
@@ -0,0 +1,239 @@
+package dash
+
+import (
+	"encoding/xml"
+	"fmt"
+	"time"
+)
+
+// MPD is a parsed Media Presentation Description
+type MPD struct {
+	Type                       string
+	MediaPresentationDuration  time.Duration
+	MinimumUpdatePeriod        time.Duration
+	AvailabilityStartTime      time.Time
+	TimeShiftBufferDepth       time.Duration
+	SuggestedPresentationDelay time.Duration
+	BaseURL                    string
+	Periods                    []Period
+}
+
+// Period is a part of media presentation
+type Period struct {
+	ID             string
+	Start          time.Duration
+	Duration       time.Duration
+	BaseURL        string
+	AdaptationSets []AdaptationSet
+}
+
+// AdaptationSet is a set of interchangeable representations of the same content
+type AdaptationSet struct {
+	ID              string
+	ContentType     string
+	MimeType        string
+	BaseURL         string
+	SegmentTemplate *SegmentTemplate
+	SegmentList     *SegmentList
+	Representations []Representation
+}
+
+// Representation is an encoded version of media content
+type Representation struct {
+	ID              string
+	Bandwidth       int
+	MimeType        string
+	BaseURL         string
+	SegmentTemplate *SegmentTemplate
+	SegmentList     *SegmentList
+}
+
+// SegmentTemplate describes segments addressing by URL template
+type SegmentTemplate struct {
+	Media                  string           `xml:"media,attr"`
+	Initialization         string           `xml:"initialization,attr"`
+	StartNumber            *int64           `xml:"startNumber,attr"`
+	Timescale              *uint64          `xml:"timescale,attr"`
+	Duration               *uint64          `xml:"duration,attr"`
+	PresentationTimeOffset *uint64          `xml:"presentationTimeOffset,attr"`
+	Timeline               *SegmentTimeline `xml:"SegmentTimeline"`
+}
+
+// SegmentTimeline describes exact times and durations of segments
+type SegmentTimeline struct {
+	S []TimelineEntry `xml:"S"`
+}
+
+// TimelineEntry describes series of segments with the same duration
+type TimelineEntry struct {
+	T *uint64 `xml:"t,attr"`
+	D uint64  `xml:"d,attr"`
+	R int64   `xml:"r,attr"`
+}
+
+// SegmentList describes segments addressing by explicit list of URLs
+type SegmentList struct {
+	Timescale      *uint64       `xml:"timescale,attr"`
+	Duration       *uint64       `xml:"duration,attr"`
+	Initialization *URLReference `xml:"Initialization"`
+	SegmentURLs    []SegmentURL  `xml:"SegmentURL"`
+}
+
+// URLReference refers to initialization segment
+type URLReference struct {
+	SourceURL string `xml:"sourceURL,attr"`
+}
+
+// SegmentURL refers to media segment of SegmentList
+type SegmentURL struct {
+	Media string `xml:"media,attr"`
+}
+
+// IsDynamic checks whether presentation is live
+func (m *MPD) IsDynamic() bool {
+	return m.Type == "dynamic"
+}
+
+type xmlMPD struct {
+	Type                       string      `xml:"type,attr"`
+	MediaPresentationDuration  string      `xml:"mediaPresentationDuration,attr"`
+	MinimumUpdatePeriod        string      `xml:"minimumUpdatePeriod,attr"`
+	AvailabilityStartTime      string      `xml:"availabilityStartTime,attr"`
+	TimeShiftBufferDepth       string      `xml:"timeShiftBufferDepth,attr"`
+	SuggestedPresentationDelay string      `xml:"suggestedPresentationDelay,attr"`
+	BaseURL                    string      `xml:"BaseURL"`
+	Periods                    []xmlPeriod `xml:"Period"`
+}
+
+type xmlPeriod struct {
+	ID             string             `xml:"id,attr"`
+	Start          string             `xml:"start,attr"`
+	Duration       string             `xml:"duration,attr"`
+	BaseURL        string             `xml:"BaseURL"`
+	AdaptationSets []xmlAdaptationSet `xml:"AdaptationSet"`
+}
+
+type xmlAdaptationSet struct {
+	ID              string              `xml:"id,attr"`
+	ContentType     string              `xml:"contentType,attr"`
+	MimeType        string              `xml:"mimeType,attr"`
+	BaseURL         string              `xml:"BaseURL"`
+	SegmentTemplate *SegmentTemplate    `xml:"SegmentTemplate"`
+	SegmentList     *SegmentList        `xml:"SegmentList"`
+	Representations []xmlRepresentation `xml:"Representation"`
+}
+
+type xmlRepresentation struct {
+	ID              string           `xml:"id,attr"`
+	Bandwidth       int              `xml:"bandwidth,attr"`
+	MimeType        string           `xml:"mimeType,attr"`
+	BaseURL         string           `xml:"BaseURL"`
+	SegmentTemplate *SegmentTemplate `xml:"SegmentTemplate"`
+	SegmentList     *SegmentList     `xml:"SegmentList"`
+}
+
+// Parse parses MPD document
+func Parse(data []byte) (*MPD, error) {
+	var doc xmlMPD
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	m := &MPD{Type: doc.Type, BaseURL: doc.BaseURL}
+	if m.Type == "" {
+		m.Type = "static"
+	}
+
+	var err error
+	durations := []struct {
+		value string
+		dst   *time.Duration
+	}{
+		{doc.MediaPresentationDuration, &m.MediaPresentationDuration},
+		{doc.MinimumUpdatePeriod, &m.MinimumUpdatePeriod},
+		{doc.TimeShiftBufferDepth, &m.TimeShiftBufferDepth},
+		{doc.SuggestedPresentationDelay, &m.SuggestedPresentationDelay},
+	}
+	for _, d := range durations {
+		if *d.dst, err = parseDuration(d.value); err != nil {
+			return nil, err
+		}
+	}
+
+	if doc.AvailabilityStartTime != "" {
+		if m.AvailabilityStartTime, err = time.Parse(time.RFC3339, doc.AvailabilityStartTime); err != nil {
+			return nil, fmt.Errorf("invalid availabilityStartTime: %w", err)
+		}
+	}
+	if m.IsDynamic() && m.AvailabilityStartTime.IsZero() {
+		return nil, fmt.Errorf("availabilityStartTime is required for dynamic MPD")
+	}
+
+	for _, p := range doc.Periods {
+		period, err := convertPeriod(p)
+		if err != nil {
+			return nil, err
+		}
+		m.Periods = append(m.Periods, period)
+	}
+	if len(m.Periods) == 0 {
+		return nil, fmt.Errorf("no periods")
+	}
+
+	for i := range m.Periods {
+		p := &m.Periods[i]
+		// period without start begins at the end of the previous one, which duration is already resolved
+		if i != 0 && doc.Periods[i].Start == "" {
+			p.Start = m.Periods[i-1].Start + m.Periods[i-1].Duration
+		}
+		if p.Duration != 0 {
+			continue
+		}
+		// period without duration lasts until the explicit start of the next one or the end of presentation
+		if i+1 < len(m.Periods) {
+			if doc.Periods[i+1].Start != "" {
+				p.Duration = m.Periods[i+1].Start - p.Start
+			}
+		} else if m.MediaPresentationDuration != 0 {
+			p.Duration = m.MediaPresentationDuration - p.Start
+		}
+	}
+
+	return m, nil
+}
+
+func convertPeriod(p xmlPeriod) (Period, error) {
+	period := Period{ID: p.ID, BaseURL: p.BaseURL}
+
+	var err error
+	if period.Start, err = parseDuration(p.Start); err != nil {
+		return period, err
+	}
+	if period.Duration, err = parseDuration(p.Duration); err != nil {
+		return period, err
+	}
+
+	for _, as := range p.AdaptationSets {
+		set := AdaptationSet{
+			ID:              as.ID,
+			ContentType:     as.ContentType,
+			MimeType:        as.MimeType,
+			BaseURL:         as.BaseURL,
+			SegmentTemplate: as.SegmentTemplate,
+			SegmentList:     as.SegmentList,
+		}
+		for _, r := range as.Representations {
+			set.Representations = append(set.Representations, Representation{
+				ID:              r.ID,
+				Bandwidth:       r.Bandwidth,
+				MimeType:        r.MimeType,
+				BaseURL:         r.BaseURL,
+				SegmentTemplate: r.SegmentTemplate,
+				SegmentList:     r.SegmentList,
+			})
+		}
+		period.AdaptationSets = append(period.AdaptationSets, set)
+	}
+
+	return period, nil
+}
@@ -0,0 +1,120 @@
+package dash
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParsePeriods(t *testing.T) {
+	type period struct {
+		start    time.Duration
+		duration time.Duration
+	}
+
+	tests := []struct {
+		name    string
+		mpd     string
+		periods []period
+	}{
+		{
+			name:    "single period with presentation duration",
+			mpd:     `<MPD mediaPresentationDuration="PT1M"><Period/></MPD>`,
+			periods: []period{{0, time.Minute}},
+		},
+		{
+			name: "periods with durations only",
+			mpd: `<MPD mediaPresentationDuration="PT1M">
+				<Period duration="PT10S"/><Period duration="PT20S"/><Period/>
+			</MPD>`,
+			periods: []period{{0, 10 * time.Second}, {10 * time.Second, 20 * time.Second}, {30 * time.Second, 30 * time.Second}},
+		},
+		{
+			name: "duration taken from next explicit start",
+			mpd: `<MPD>
+				<Period/><Period start="PT15S" duration="PT5S"/><Period/>
+			</MPD>`,
+			periods: []period{{0, 15 * time.Second}, {15 * time.Second, 5 * time.Second}, {20 * time.Second, 0}},
+		},
+		{
+			name: "mixed starts and durations",
+			mpd: `<MPD mediaPresentationDuration="PT1H">
+				<Period start="PT0S" duration="PT30M"/><Period/><Period start="PT50M"/>
+			</MPD>`,
+			periods: []period{{0, 30 * time.Minute}, {30 * time.Minute, 20 * time.Minute}, {50 * time.Minute, 10 * time.Minute}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m, err := Parse([]byte(test.mpd))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(m.Periods) != len(test.periods) {
+				t.Fatalf("got %d periods, want %d", len(m.Periods), len(test.periods))
+			}
+			for i, p := range m.Periods {
+				if p.Start != test.periods[i].start || p.Duration != test.periods[i].duration {
+					t.Errorf("period %d: got start %s duration %s, want start %s duration %s",
+						i, p.Start, p.Duration, test.periods[i].start, test.periods[i].duration)
+				}
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		mpd  string
+	}{
+		{"malformed XML", `<MPD`},
+		{"no periods", `<MPD mediaPresentationDuration="PT1M"></MPD>`},
+		{"invalid duration", `<MPD mediaPresentationDuration="1 minute"><Period/></MPD>`},
+		{"invalid period start", `<MPD><Period start="PT"/></MPD>`},
+		{"dynamic without availability start", `<MPD type="dynamic"><Period/></MPD>`},
+		{"invalid availability start", `<MPD type="dynamic" availabilityStartTime="yesterday"><Period/></MPD>`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := Parse([]byte(test.mpd)); err == nil {
+				t.Error("error expected")
+			}
+		})
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		value    string
+		duration time.Duration
+		invalid  bool
+	}{
+		{value: "", duration: 0},
+		{value: "PT2S", duration: 2 * time.Second},
+		{value: "PT1H2M3.5S", duration: time.Hour + 2*time.Minute + 3500*time.Millisecond},
+		{value: "P1DT1M", duration: 24*time.Hour + time.Minute},
+		{value: "P", invalid: true},
+		{value: "PT", invalid: true},
+		{value: "2S", invalid: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			d, err := parseDuration(test.value)
+			if test.invalid {
+				if err == nil {
+					t.Errorf("error expected, got %s", d)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if d != test.duration {
+				t.Errorf("got %s, want %s", d, test.duration)
+			}
+		})
+	}
+}
@@ -0,0 +1,364 @@
+package dash
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// Track is a representation chosen for playback within a period
+type Track struct {
+	Period         *Period
+	AdaptationSet  *AdaptationSet
+	Representation *Representation
+
+	base     *url.URL
+	template *SegmentTemplate
+	list     *SegmentList
+}
+
+// Segment is a media segment of track
+type Segment struct {
+	URL string
+
+	// Start is a presentation time of segment relative to the period start
+	Start    time.Duration
+	Duration time.Duration
+}
+
+// defaultTimeShiftBufferDepth limits available segments of live presentation if MPD does not
+const defaultTimeShiftBufferDepth = 5 * time.Minute
+
+// unbounded is a limit of segments when duration of static presentation is not known
+const unbounded = time.Duration(math.MaxInt64)
+
+var templateIdentifier = regexp.MustCompile(`\$(RepresentationID|Number|Time|Bandwidth)(%0(\d+)d)?\$`)
+
+// CurrentPeriod returns index of the period which is played at the moment
+func (m *MPD) CurrentPeriod(now time.Time) int {
+	if !m.IsDynamic() {
+		return 0
+	}
+	elapsed := now.Sub(m.AvailabilityStartTime)
+	current := 0
+	for i, p := range m.Periods {
+		if p.Start <= elapsed {
+			current = i
+		}
+	}
+	return current
+}
+
+// Tracks chooses representation for each adaptation set of the period. The choose function returns index
+// of the representation to play by their bandwidths
+func (m *MPD) Tracks(period int, mpdURL *url.URL, choose func(bandwidths []int) int) ([]Track, error) {
+	if period < 0 || period >= len(m.Periods) {
+		return nil, fmt.Errorf("period %d does not exist", period)
+	}
+	p := &m.Periods[period]
+
+	base, err := resolveChain(mpdURL, m.BaseURL, p.BaseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	var tracks []Track
+	for i := range p.AdaptationSets {
+		as := &p.AdaptationSets[i]
+		if len(as.Representations) == 0 {
+			continue
+		}
+
+		bandwidths := make([]int, 0, len(as.Representations))
+		for _, r := range as.Representations {
+			bandwidths = append(bandwidths, r.Bandwidth)
+		}
+		r := &as.Representations[choose(bandwidths)]
+
+		trackBase, err := resolveChain(base, as.BaseURL, r.BaseURL)
+		if err != nil {
+			return nil, err
+		}
+
+		t := Track{
+			Period:         p,
+			AdaptationSet:  as,
+			Representation: r,
+			base:           trackBase,
+			template:       mergeTemplates(as.SegmentTemplate, r.SegmentTemplate),
+			list:           r.SegmentList,
+		}
+		if t.list == nil {
+			t.list = as.SegmentList
+		}
+		if t.template == nil && t.list == nil {
+			return nil, fmt.Errorf("representation %s: only SegmentTemplate and SegmentList are supported", r.ID)
+		}
+		tracks = append(tracks, t)
+	}
+
+	if len(tracks) == 0 {
+		return nil, errors.New("no representations to play")
+	}
+	return tracks, nil
+}
+
+// InitURL returns URL of initialization segment if any
+func (t *Track) InitURL() string {
+	if t.template != nil && t.template.Initialization != "" {
+		return t.resolve(t.expand(t.template.Initialization, 0, 0))
+	}
+	if t.list != nil && t.list.Initialization != nil && t.list.Initialization.SourceURL != "" {
+		return t.resolve(t.list.Initialization.SourceURL)
+	}
+	return ""
+}
+
+// Segments returns segments which are available at the moment
+func (t *Track) Segments(m *MPD, now time.Time) ([]Segment, error) {
+	// limit of segment ends relative to the period start, unbounded if duration of static period is unknown
+	limit := unbounded
+	if m.IsDynamic() {
+		limit = now.Sub(m.AvailabilityStartTime) - t.Period.Start
+	}
+	if t.Period.Duration != 0 && t.Period.Duration < limit {
+		limit = t.Period.Duration
+	}
+
+	// segments ending before are out of time shift buffer
+	var from time.Duration
+	if m.IsDynamic() {
+		depth := m.TimeShiftBufferDepth
+		if depth == 0 {
+			depth = defaultTimeShiftBufferDepth
+		}
+		from = limit - depth
+	}
+
+	var segments []Segment
+	var err error
+	switch {
+	case t.template != nil && t.template.Timeline != nil:
+		segments = t.timelineSegments(limit)
+	case t.template != nil:
+		if limit == unbounded {
+			// count of segments is unknown without duration of the period
+			limit = 0
+		}
+		segments, err = t.templateSegments(from, limit, m.IsDynamic())
+	default:
+		segments, err = t.listSegments(limit, m.IsDynamic())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	first := 0
+	for first < len(segments) && segments[first].Start+segments[first].Duration < from {
+		first++
+	}
+	return segments[first:], nil
+}
+
+func (t *Track) timelineSegments(limit time.Duration) []Segment {
+	timescale := valueOr(t.template.Timescale, 1)
+	offset := valueOr(t.template.PresentationTimeOffset, 0)
+	number := int64(1)
+	if t.template.StartNumber != nil {
+		number = *t.template.StartNumber
+	}
+
+	entries := t.template.Timeline.S
+	var segments []Segment
+	var ts uint64
+	for i, s := range entries {
+		if s.T != nil {
+			ts = *s.T
+		}
+		if s.D == 0 {
+			continue
+		}
+
+		repeat := s.R
+		if repeat < 0 {
+			// repeat until the next entry or the limit
+			var end uint64
+			switch {
+			case i+1 < len(entries) && entries[i+1].T != nil:
+				end = *entries[i+1].T
+			case limit != unbounded:
+				end = offset + uint64(limit.Seconds()*float64(timescale))
+			default:
+				// the end of period is unknown, so the timeline is bounded by its own entries
+				end = ts + s.D
+			}
+			repeat = int64(math.Ceil((float64(end)-float64(ts))/float64(s.D))) - 1
+		}
+
+		for k := int64(0); k <= repeat; k++ {
+			start := scaled(ts-offset, timescale)
+			duration := scaled(s.D, timescale)
+			if start+duration > limit {
+				return segments
+			}
+			segments = append(segments, Segment{
+				URL:      t.resolve(t.expand(t.template.Media, number, ts)),
+				Start:    start,
+				Duration: duration,
+			})
+			ts += s.D
+			number++
+		}
+	}
+	return segments
+}
+
+func (t *Track) templateSegments(from, limit time.Duration, dynamic bool) ([]Segment, error) {
+	if t.template.Duration == nil || *t.template.Duration == 0 {
+		return nil, errors.New("segment duration is not specified")
+	}
+	if limit <= 0 {
+		return nil, nil
+	}
+
+	timescale := valueOr(t.template.Timescale, 1)
+	offset := valueOr(t.template.PresentationTimeOffset, 0)
+	number := int64(1)
+	if t.template.StartNumber != nil {
+		number = *t.template.StartNumber
+	}
+
+	duration := scaled(*t.template.Duration, timescale)
+	count := int64(limit / duration)
+	if !dynamic && limit%duration != 0 {
+		// the last segment of static presentation may be shorter
+		count++
+	}
+	first := int64(0)
+	if from > 0 {
+		first = int64(from / duration)
+	}
+
+	segments := make([]Segment, 0, count-first)
+	for i := first; i < count; i++ {
+		ts := offset + uint64(i)*(*t.template.Duration)
+		segments = append(segments, Segment{
+			URL:      t.resolve(t.expand(t.template.Media, number+i, ts)),
+			Start:    time.Duration(i) * duration,
+			Duration: duration,
+		})
+	}
+	return segments, nil
+}
+
+func (t *Track) listSegments(limit time.Duration, dynamic bool) ([]Segment, error) {
+	if t.list.Duration == nil || *t.list.Duration == 0 {
+		return nil, errors.New("segment duration is not specified")
+	}
+
+	timescale := valueOr(t.list.Timescale, 1)
+	duration := scaled(*t.list.Duration, timescale)
+
+	segments := make([]Segment, 0, len(t.list.SegmentURLs))
+	for i, s := range t.list.SegmentURLs {
+		start := time.Duration(i) * duration
+		if dynamic && start+duration > limit {
+			break
+		}
+		segments = append(segments, Segment{URL: t.resolve(s.Media), Start: start, Duration: duration})
+	}
+	return segments, nil
+}
+
+func (t *Track) expand(template string, number int64, ts uint64) string {
+	return templateIdentifier.ReplaceAllStringFunc(template, func(s string) string {
+		match := templateIdentifier.FindStringSubmatch(s)
+		var value string
+		switch match[1] {
+		case "RepresentationID":
+			return t.Representation.ID
+		case "Number":
+			value = strconv.FormatInt(number, 10)
+		case "Time":
+			value = strconv.FormatUint(ts, 10)
+		case "Bandwidth":
+			value = strconv.Itoa(t.Representation.Bandwidth)
+		}
+		if match[3] != "" {
+			width, _ := strconv.Atoi(match[3])
+			for len(value) < width {
+				value = "0" + value
+			}
+		}
+		return value
+	})
+}
+
+func (t *Track) resolve(ref string) string {
+	u, err := url.Parse(ref)
+	if err != nil {
+		return ref
+	}
+	return t.base.ResolveReference(u).String()
+}
+
+func resolveChain(base *url.URL, refs ...string) (*url.URL, error) {
+	for _, ref := range refs {
+		if ref == "" {
+			continue
+		}
+		u, err := url.Parse(ref)
+		if err != nil {
+			return nil, fmt.Errorf("invalid base URL '%s': %w", ref, err)
+		}
+		base = base.ResolveReference(u)
+	}
+	return base, nil
+}
+
+func mergeTemplates(parent, child *SegmentTemplate) *SegmentTemplate {
+	if parent == nil {
+		return child
+	}
+	if child == nil {
+		return parent
+	}
+	merged := *parent
+	if child.Media != "" {
+		merged.Media = child.Media
+	}
+	if child.Initialization != "" {
+		merged.Initialization = child.Initialization
+	}
+	if child.StartNumber != nil {
+		merged.StartNumber = child.StartNumber
+	}
+	if child.Timescale != nil {
+		merged.Timescale = child.Timescale
+	}
+	if child.Duration != nil {
+		merged.Duration = child.Duration
+	}
+	if child.PresentationTimeOffset != nil {
+		merged.PresentationTimeOffset = child.PresentationTimeOffset
+	}
+	if child.Timeline != nil {
+		merged.Timeline = child.Timeline
+	}
+	return &merged
+}
+
+func valueOr(v *uint64, def uint64) uint64 {
+	if v == nil {
+		return def
+	}
+	return *v
+}
+
+func scaled(v, timescale uint64) time.Duration {
+	return time.Duration(float64(v) / float64(timescale) * float64(time.Second))
+}
@@ -0,0 +1,207 @@
+package dash
+
+import (
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSegments(t *testing.T) {
+	mpdURL, _ := url.Parse("http://example.com/live/manifest.mpd")
+	availabilityStart := time.Date(2022, 5, 12, 19, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		mpd      string
+		now      time.Time
+		urls     []string
+		duration time.Duration // of each segment
+	}{
+		{
+			name: "static template",
+			mpd: `<MPD mediaPresentationDuration="PT10S"><Period><AdaptationSet>
+				<SegmentTemplate media="$RepresentationID$/$Number%03d$.m4s" duration="4" startNumber="0"/>
+				<Representation id="hd" bandwidth="1000"/>
+			</AdaptationSet></Period></MPD>`,
+			urls: []string{
+				"http://example.com/live/hd/000.m4s",
+				"http://example.com/live/hd/001.m4s",
+				"http://example.com/live/hd/002.m4s",
+			},
+			duration: 4 * time.Second,
+		},
+		{
+			name: "dynamic template within time shift buffer",
+			mpd: `<MPD type="dynamic" availabilityStartTime="2022-05-12T19:00:00Z" timeShiftBufferDepth="PT6S">
+				<Period><AdaptationSet>
+					<SegmentTemplate media="$Number$.m4s" duration="2000" timescale="1000"/>
+					<Representation id="hd" bandwidth="1000"/>
+				</AdaptationSet></Period></MPD>`,
+			now: availabilityStart.Add(11 * time.Second),
+			urls: []string{
+				"http://example.com/live/3.m4s",
+				"http://example.com/live/4.m4s",
+				"http://example.com/live/5.m4s",
+			},
+			duration: 2 * time.Second,
+		},
+		{
+			name: "static timeline without duration is bounded by its entries",
+			mpd: `<MPD><Period><AdaptationSet>
+				<SegmentTemplate media="$Time$.m4s" timescale="10">
+					<SegmentTimeline><S t="100" d="20" r="1"/><S d="20" r="-1"/></SegmentTimeline>
+				</SegmentTemplate>
+				<Representation id="hd" bandwidth="1000"/>
+			</AdaptationSet></Period></MPD>`,
+			urls: []string{
+				"http://example.com/live/100.m4s",
+				"http://example.com/live/120.m4s",
+				"http://example.com/live/140.m4s",
+			},
+			duration: 2 * time.Second,
+		},
+		{
+			name: "open repeat is bounded by period duration",
+			mpd: `<MPD mediaPresentationDuration="PT6S"><Period><AdaptationSet>
+				<SegmentTemplate media="$Number$.m4s" timescale="10">
+					<SegmentTimeline><S t="0" d="20" r="-1"/></SegmentTimeline>
+				</SegmentTemplate>
+				<Representation id="hd" bandwidth="1000"/>
+			</AdaptationSet></Period></MPD>`,
+			urls: []string{
+				"http://example.com/live/1.m4s",
+				"http://example.com/live/2.m4s",
+				"http://example.com/live/3.m4s",
+			},
+			duration: 2 * time.Second,
+		},
+		{
+			name: "open repeat is bounded by next entry",
+			mpd: `<MPD><Period><AdaptationSet>
+				<SegmentTemplate media="$Time$.m4s">
+					<SegmentTimeline><S t="0" d="2" r="-1"/><S t="4" d="2"/></SegmentTimeline>
+				</SegmentTemplate>
+				<Representation id="hd" bandwidth="1000"/>
+			</AdaptationSet></Period></MPD>`,
+			urls: []string{
+				"http://example.com/live/0.m4s",
+				"http://example.com/live/2.m4s",
+				"http://example.com/live/4.m4s",
+			},
+			duration: 2 * time.Second,
+		},
+		{
+			name: "dynamic timeline up to now",
+			mpd: `<MPD type="dynamic" availabilityStartTime="2022-05-12T19:00:00Z"><Period><AdaptationSet>
+				<SegmentTemplate media="$Time$.m4s">
+					<SegmentTimeline><S t="0" d="2" r="-1"/></SegmentTimeline>
+				</SegmentTemplate>
+				<Representation id="hd" bandwidth="1000"/>
+			</AdaptationSet></Period></MPD>`,
+			now: availabilityStart.Add(5 * time.Second),
+			urls: []string{
+				"http://example.com/live/0.m4s",
+				"http://example.com/live/2.m4s",
+			},
+			duration: 2 * time.Second,
+		},
+		{
+			name: "segment list",
+			mpd: `<MPD><Period><BaseURL>media/</BaseURL><AdaptationSet><Representation id="hd" bandwidth="1000">
+				<SegmentList duration="3"><SegmentURL media="a.ts"/><SegmentURL media="b.ts"/></SegmentList>
+			</Representation></AdaptationSet></Period></MPD>`,
+			urls: []string{
+				"http://example.com/live/media/a.ts",
+				"http://example.com/live/media/b.ts",
+			},
+			duration: 3 * time.Second,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m, err := Parse([]byte(test.mpd))
+			if err != nil {
+				t.Fatal(err)
+			}
+			tracks, err := m.Tracks(0, mpdURL, func([]int) int { return 0 })
+			if err != nil {
+				t.Fatal(err)
+			}
+			segments, err := tracks[0].Segments(m, test.now)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var urls []string
+			for _, s := range segments {
+				urls = append(urls, s.URL)
+				if s.Duration != test.duration {
+					t.Errorf("segment %s: got duration %s, want %s", s.URL, s.Duration, test.duration)
+				}
+			}
+			if !reflect.DeepEqual(urls, test.urls) {
+				t.Errorf("got segments %v, want %v", urls, test.urls)
+			}
+		})
+	}
+}
+
+func TestTracksChooseRepresentation(t *testing.T) {
+	mpdURL, _ := url.Parse("http://example.com/manifest.mpd")
+	m, err := Parse([]byte(`<MPD mediaPresentationDuration="PT4S"><Period>
+		<AdaptationSet contentType="video">
+			<SegmentTemplate media="$RepresentationID$/$Bandwidth$/$Number$.m4s" initialization="$RepresentationID$/init.mp4" duration="2"/>
+			<Representation id="low" bandwidth="500"/>
+			<Representation id="high" bandwidth="3000"/>
+		</AdaptationSet>
+		<AdaptationSet contentType="audio"/>
+	</Period></MPD>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		choose  func(bandwidths []int) int
+		initURL string
+		first   string
+	}{
+		{
+			name:    "lowest",
+			choose:  func([]int) int { return 0 },
+			initURL: "http://example.com/low/init.mp4",
+			first:   "http://example.com/low/500/1.m4s",
+		},
+		{
+			name:    "highest",
+			choose:  func(bandwidths []int) int { return len(bandwidths) - 1 },
+			initURL: "http://example.com/high/init.mp4",
+			first:   "http://example.com/high/3000/1.m4s",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tracks, err := m.Tracks(0, mpdURL, test.choose)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// adaptation set without representations is skipped
+			if len(tracks) != 1 {
+				t.Fatalf("got %d tracks, want 1", len(tracks))
+			}
+			if u := tracks[0].InitURL(); u != test.initURL {
+				t.Errorf("got init URL %s, want %s", u, test.initURL)
+			}
+			segments, err := tracks[0].Segments(m, time.Time{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(segments) != 2 || segments[0].URL != test.first {
+				t.Errorf("got segments %+v, want 2 starting with %s", segments, test.first)
+			}
+		})
+	}
+}
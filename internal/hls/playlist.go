@@ -0,0 +1,178 @@
+package hls
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Variant is a variant stream of master playlist
+type Variant struct {
+	URL        string
+	Bandwidth  int
+	Resolution string
+	Codecs     string
+}
+
+// Segment is a media segment of media playlist
+type Segment struct {
+	URL      string
+	Sequence int64
+	Duration time.Duration
+}
+
+// Playlist is a parsed master or media playlist
+type Playlist struct {
+	// Variants are presented in master playlist only
+	Variants []Variant
+
+	TargetDuration time.Duration
+	MediaSequence  int64
+	Segments       []Segment
+	EndList        bool
+
+	// InitURL is a URL of media initialization section (EXT-X-MAP)
+	InitURL string
+}
+
+// IsMaster checks whether playlist is master playlist
+func (p *Playlist) IsMaster() bool {
+	return len(p.Variants) != 0
+}
+
+// Parse reads playlist and resolves its URIs against the base URL
+func Parse(r io.Reader, base *url.URL) (*Playlist, error) {
+	scanner := bufio.NewScanner(r)
+	if !scanner.Scan() || strings.TrimSpace(strings.TrimPrefix(scanner.Text(), "\ufeff")) != "#EXTM3U" {
+		return nil, errors.New("not an M3U8 playlist")
+	}
+
+	p := &Playlist{}
+	var variant *Variant
+	var duration time.Duration
+	sequenceSet := false
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		if !strings.HasPrefix(line, "#") {
+			uri, err := resolve(base, line)
+			if err != nil {
+				return nil, err
+			}
+			if variant != nil {
+				variant.URL = uri
+				p.Variants = append(p.Variants, *variant)
+				variant = nil
+			} else {
+				p.Segments = append(p.Segments, Segment{
+					URL:      uri,
+					Sequence: p.MediaSequence + int64(len(p.Segments)),
+					Duration: duration,
+				})
+				duration = 0
+			}
+			continue
+		}
+
+		tag, value, _ := strings.Cut(line, ":")
+		switch tag {
+		case "#EXT-X-STREAM-INF":
+			attrs := parseAttributes(value)
+			bandwidth, err := strconv.Atoi(attrs["BANDWIDTH"])
+			if err != nil {
+				return nil, fmt.Errorf("invalid variant bandwidth: %w", err)
+			}
+			variant = &Variant{Bandwidth: bandwidth, Resolution: attrs["RESOLUTION"], Codecs: attrs["CODECS"]}
+
+		case "#EXT-X-TARGETDURATION":
+			seconds, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid target duration: %w", err)
+			}
+			p.TargetDuration = time.Duration(seconds) * time.Second
+
+		case "#EXT-X-MEDIA-SEQUENCE":
+			seq, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid media sequence: %w", err)
+			}
+			if len(p.Segments) == 0 && !sequenceSet {
+				p.MediaSequence = seq
+				sequenceSet = true
+			}
+
+		case "#EXTINF":
+			d, _, _ := strings.Cut(value, ",")
+			seconds, err := strconv.ParseFloat(d, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid segment duration: %w", err)
+			}
+			duration = time.Duration(seconds * float64(time.Second))
+
+		case "#EXT-X-MAP":
+			uri, err := resolve(base, parseAttributes(value)["URI"])
+			if err != nil {
+				return nil, err
+			}
+			p.InitURL = uri
+
+		case "#EXT-X-ENDLIST":
+			p.EndList = true
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if !p.IsMaster() && p.TargetDuration == 0 {
+		return nil, errors.New("target duration is not specified")
+	}
+
+	return p, nil
+}
+
+func resolve(base *url.URL, ref string) (string, error) {
+	u, err := url.Parse(ref)
+	if err != nil {
+		return "", fmt.Errorf("invalid URI '%s': %w", ref, err)
+	}
+	if base == nil {
+		return u.String(), nil
+	}
+	return base.ResolveReference(u).String(), nil
+}
+
+// parseAttributes parses attribute list like BANDWIDTH=1280000,CODECS="avc1.4d401f,mp4a.40.2"
+func parseAttributes(list string) map[string]string {
+	attrs := make(map[string]string)
+	for len(list) > 0 {
+		name, rest, found := strings.Cut(list, "=")
+		if !found {
+			break
+		}
+		var value string
+		if strings.HasPrefix(rest, "\"") {
+			end := strings.Index(rest[1:], "\"")
+			if end < 0 {
+				value, rest = rest[1:], ""
+			} else {
+				value, rest = rest[1:end+1], rest[end+2:]
+			}
+			rest = strings.TrimPrefix(rest, ",")
+		} else {
+			value, rest, _ = strings.Cut(rest, ",")
+		}
+		attrs[strings.TrimSpace(name)] = value
+		list = rest
+	}
+	return attrs
+}
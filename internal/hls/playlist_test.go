@@ -0,0 +1,142 @@
+package hls
+
+import (
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	base, _ := url.Parse("http://example.com/live/master.m3u8")
+
+	tests := []struct {
+		name     string
+		playlist string
+		expected Playlist
+	}{
+		{
+			name: "master",
+			playlist: `#EXTM3U
+#EXT-X-STREAM-INF:BANDWIDTH=1280000,RESOLUTION=640x360,CODECS="avc1.4d401f,mp4a.40.2"
+low/index.m3u8
+
+#EXT-X-STREAM-INF:BANDWIDTH=5000000,RESOLUTION=1920x1080
+http://cdn.example.com/high/index.m3u8
+`,
+			expected: Playlist{
+				Variants: []Variant{
+					{URL: "http://example.com/live/low/index.m3u8", Bandwidth: 1280000, Resolution: "640x360", Codecs: "avc1.4d401f,mp4a.40.2"},
+					{URL: "http://cdn.example.com/high/index.m3u8", Bandwidth: 5000000, Resolution: "1920x1080"},
+				},
+			},
+		},
+		{
+			name: "live media",
+			playlist: "\ufeff#EXTM3U\n" + `#EXT-X-TARGETDURATION:6
+#EXT-X-MEDIA-SEQUENCE:100
+#EXT-X-MAP:URI="init.mp4"
+#EXTINF:6.000,
+seg100.m4s
+#EXTINF:5.5,title
+/abs/seg101.m4s
+`,
+			expected: Playlist{
+				TargetDuration: 6 * time.Second,
+				MediaSequence:  100,
+				InitURL:        "http://example.com/live/init.mp4",
+				Segments: []Segment{
+					{URL: "http://example.com/live/seg100.m4s", Sequence: 100, Duration: 6 * time.Second},
+					{URL: "http://example.com/abs/seg101.m4s", Sequence: 101, Duration: 5500 * time.Millisecond},
+				},
+			},
+		},
+		{
+			name: "finished media",
+			playlist: `#EXTM3U
+#EXT-X-TARGETDURATION:4
+#EXTINF:4,
+0.ts
+#EXT-X-ENDLIST
+`,
+			expected: Playlist{
+				TargetDuration: 4 * time.Second,
+				EndList:        true,
+				Segments:       []Segment{{URL: "http://example.com/live/0.ts", Sequence: 0, Duration: 4 * time.Second}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p, err := Parse(strings.NewReader(test.playlist), base)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(*p, test.expected) {
+				t.Errorf("got %+v, want %+v", *p, test.expected)
+			}
+			if p.IsMaster() != (len(test.expected.Variants) != 0) {
+				t.Errorf("IsMaster() = %t", p.IsMaster())
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		playlist string
+	}{
+		{"not a playlist", "<html></html>"},
+		{"empty", ""},
+		{"no target duration", "#EXTM3U\n#EXTINF:4,\n0.ts\n"},
+		{"invalid target duration", "#EXTM3U\n#EXT-X-TARGETDURATION:four\n"},
+		{"invalid media sequence", "#EXTM3U\n#EXT-X-TARGETDURATION:4\n#EXT-X-MEDIA-SEQUENCE:x\n"},
+		{"invalid segment duration", "#EXTM3U\n#EXT-X-TARGETDURATION:4\n#EXTINF:abc,\n0.ts\n"},
+		{"invalid bandwidth", "#EXTM3U\n#EXT-X-STREAM-INF:RESOLUTION=640x360\nlow.m3u8\n"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := Parse(strings.NewReader(test.playlist), nil); err == nil {
+				t.Error("error expected")
+			}
+		})
+	}
+}
+
+func TestVariantPolicy(t *testing.T) {
+	bandwidths := []int{2000000, 500000, 5000000, 1000000}
+
+	tests := []struct {
+		policy string
+		chosen int
+	}{
+		{policy: "highest", chosen: 2},
+		{policy: "", chosen: 2},
+		{policy: "lowest", chosen: 1},
+		{policy: "1500000", chosen: 3},
+		{policy: "2000000", chosen: 0},
+		{policy: "100", chosen: 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.policy, func(t *testing.T) {
+			p, err := ParseVariantPolicy(test.policy)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if chosen := p.Choose(bandwidths); chosen != test.chosen {
+				t.Errorf("got %d, want %d", chosen, test.chosen)
+			}
+		})
+	}
+
+	for _, invalid := range []string{"best", "0", "-1"} {
+		if _, err := ParseVariantPolicy(invalid); err == nil {
+			t.Errorf("error expected for %q", invalid)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package hls
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// VariantPolicy describes how variant stream is chosen from master playlist
+type VariantPolicy struct {
+	// Bandwidth selects the best variant which does not exceed it. Zero means the highest variant, negative - the lowest
+	Bandwidth int
+}
+
+var (
+	// HighestVariant selects variant with the maximum bandwidth
+	HighestVariant = VariantPolicy{}
+
+	// LowestVariant selects variant with the minimum bandwidth
+	LowestVariant = VariantPolicy{Bandwidth: -1}
+)
+
+// ParseVariantPolicy parses policy from "highest", "lowest" or bandwidth in bits per second
+func ParseVariantPolicy(s string) (VariantPolicy, error) {
+	switch s {
+	case "", "highest":
+		return HighestVariant, nil
+	case "lowest":
+		return LowestVariant, nil
+	}
+	bandwidth, err := strconv.Atoi(s)
+	if err != nil || bandwidth <= 0 {
+		return VariantPolicy{}, fmt.Errorf("invalid variant: %s", s)
+	}
+	return VariantPolicy{Bandwidth: bandwidth}, nil
+}
+
+func (p VariantPolicy) String() string {
+	switch {
+	case p.Bandwidth == 0:
+		return "highest"
+	case p.Bandwidth < 0:
+		return "lowest"
+	default:
+		return strconv.Itoa(p.Bandwidth)
+	}
+}
+
+// Select chooses variant according to the policy. If no variant fits the bandwidth, the lowest one is chosen
+func (p VariantPolicy) Select(variants []Variant) (Variant, bool) {
+	if len(variants) == 0 {
+		return Variant{}, false
+	}
+
+	bandwidths := make([]int, 0, len(variants))
+	for _, v := range variants {
+		bandwidths = append(bandwidths, v.Bandwidth)
+	}
+	return variants[p.Choose(bandwidths)], true
+}
+
+// Choose returns index of the bandwidth which fits the policy. The list must not be empty
+func (p VariantPolicy) Choose(bandwidths []int) int {
+	lowest, highest, best := 0, 0, -1
+	for i, bandwidth := range bandwidths {
+		if bandwidth < bandwidths[lowest] {
+			lowest = i
+		}
+		if bandwidth > bandwidths[highest] {
+			highest = i
+		}
+		if p.Bandwidth > 0 && bandwidth <= p.Bandwidth && (best < 0 || bandwidth > bandwidths[best]) {
+			best = i
+		}
+	}
+
+	switch {
+	case p.Bandwidth == 0:
+		return highest
+	case p.Bandwidth < 0 || best < 0:
+		return lowest
+	default:
+		return best
+	}
+}
@@ -0,0 +1,193 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// labelEscaper escapes label value as Prometheus text format requires, other characters are written as is
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// collector is a metric family which can be exposed in Prometheus text format
+type collector interface {
+	write(w io.Writer)
+}
+
+// Registry holds metric families and exposes them in Prometheus text format
+type Registry struct {
+	mutex      sync.Mutex
+	collectors []collector
+}
+
+// NewRegistry creates empty registry
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// NewGauge registers gauge family with the label names
+func (r *Registry) NewGauge(name, help string, labels ...string) *Vec {
+	v := newVec("gauge", name, help, labels)
+	r.register(v)
+	return v
+}
+
+// NewCounter registers counter family with the label names
+func (r *Registry) NewCounter(name, help string, labels ...string) *Vec {
+	v := newVec("counter", name, help, labels)
+	r.register(v)
+	return v
+}
+
+// NewHistogram registers histogram with the upper bounds of buckets
+func (r *Registry) NewHistogram(name, help string, buckets []float64) *Histogram {
+	h := newHistogram(name, help, buckets)
+	r.register(h)
+	return h
+}
+
+// Write writes all metrics in Prometheus text format
+func (r *Registry) Write(w io.Writer) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for _, c := range r.collectors {
+		c.write(w)
+	}
+}
+
+// ServeHTTP implements http.Handler
+func (r *Registry) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	r.Write(w)
+}
+
+func (r *Registry) register(c collector) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.collectors = append(r.collectors, c)
+}
+
+// Vec is a family of gauges or counters partitioned by label values
+type Vec struct {
+	kind   string
+	name   string
+	help   string
+	labels []string
+
+	mutex  sync.Mutex
+	values map[string]float64
+}
+
+func newVec(kind, name, help string, labels []string) *Vec {
+	return &Vec{kind: kind, name: name, help: help, labels: labels, values: make(map[string]float64)}
+}
+
+// Set sets value of the metric with the label values
+func (v *Vec) Set(value float64, labelValues ...string) {
+	key := v.key(labelValues)
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	v.values[key] = value
+}
+
+// Add increments value of the metric with the label values
+func (v *Vec) Add(value float64, labelValues ...string) {
+	key := v.key(labelValues)
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	v.values[key] += value
+}
+
+// Reset drops all metrics of the family, so label values which are gone are not exposed anymore
+func (v *Vec) Reset() {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	v.values = make(map[string]float64)
+}
+
+func (v *Vec) key(labelValues []string) string {
+	if len(labelValues) != len(v.labels) {
+		panic(fmt.Sprintf("metric %s: expected %d label values, got %d", v.name, len(v.labels), len(labelValues)))
+	}
+	pairs := make([]string, 0, len(labelValues))
+	for i, value := range labelValues {
+		pairs = append(pairs, v.labels[i]+`="`+labelEscaper.Replace(value)+`"`)
+	}
+	return strings.Join(pairs, ",")
+}
+
+func (v *Vec) write(w io.Writer) {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	writeHeader(w, v.name, v.help, v.kind)
+	keys := make([]string, 0, len(v.values))
+	for k := range v.values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if k == "" {
+			_, _ = fmt.Fprintf(w, "%s %s\n", v.name, formatValue(v.values[k]))
+		} else {
+			_, _ = fmt.Fprintf(w, "%s{%s} %s\n", v.name, k, formatValue(v.values[k]))
+		}
+	}
+}
+
+// Histogram counts observations in configurable buckets
+type Histogram struct {
+	name    string
+	help    string
+	buckets []float64
+
+	mutex  sync.Mutex
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+func newHistogram(name, help string, buckets []float64) *Histogram {
+	b := append([]float64(nil), buckets...)
+	sort.Float64s(b)
+	return &Histogram{name: name, help: help, buckets: b, counts: make([]uint64, len(b))}
+}
+
+// Observe adds the value to histogram
+func (h *Histogram) Observe(value float64) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	for i, bound := range h.buckets {
+		if value <= bound {
+			h.counts[i]++
+		}
+	}
+	h.count++
+	h.sum += value
+}
+
+func (h *Histogram) write(w io.Writer) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	writeHeader(w, h.name, h.help, "histogram")
+	for i, bound := range h.buckets {
+		_, _ = fmt.Fprintf(w, "%s_bucket{le=\"%s\"} %d\n", h.name, formatValue(bound), h.counts[i])
+	}
+	_, _ = fmt.Fprintf(w, "%s_bucket{le=\"+Inf\"} %d\n", h.name, h.count)
+	_, _ = fmt.Fprintf(w, "%s_sum %s\n", h.name, formatValue(h.sum))
+	_, _ = fmt.Fprintf(w, "%s_count %d\n", h.name, h.count)
+}
+
+func writeHeader(w io.Writer, name, help, kind string) {
+	_, _ = fmt.Fprintf(w, "# HELP %s %s\n", name, help)
+	_, _ = fmt.Fprintf(w, "# TYPE %s %s\n", name, kind)
+}
+
+func formatValue(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
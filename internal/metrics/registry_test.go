@@ -0,0 +1,33 @@
+package metrics
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestLabelEscaping(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected string
+	}{
+		{value: "10.0.0.1", expected: `m{addr="10.0.0.1"} 1`},
+		{value: `back\slash`, expected: `m{addr="back\\slash"} 1`},
+		{value: `"quoted"`, expected: `m{addr="\"quoted\""} 1`},
+		{value: "new\nline", expected: `m{addr="new\nline"} 1`},
+		{value: "tab\tand юникод", expected: "m{addr=\"tab\tand юникод\"} 1"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			r := NewRegistry()
+			r.NewGauge("m", "help", "addr").Set(1, test.value)
+
+			var buf bytes.Buffer
+			r.Write(&buf)
+			expected := "# HELP m help\n# TYPE m gauge\n" + test.expected + "\n"
+			if buf.String() != expected {
+				t.Errorf("got %q, want %q", buf.String(), expected)
+			}
+		})
+	}
+}
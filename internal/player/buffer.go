@@ -0,0 +1,107 @@
+package player
+
+import "time"
+
+// DefaultStartBuffer is an amount of media which player accumulates before starting or resuming playback
+const DefaultStartBuffer = 2 * time.Second
+
+// Stats is a state of simulated playback
+type Stats struct {
+	// Bitrate is a nominal bitrate of the stream (bits per second), zero if it is not known yet
+	Bitrate float64
+	// Playing is set when buffer has enough media and playback goes on
+	Playing bool
+	// BufferLevel is an amount of buffered media
+	BufferLevel time.Duration
+	// StartupDelay is a time from start till the beginning of playback, zero if playback is not started yet
+	StartupDelay time.Duration
+	// Rebuffers is a count of playback interruptions caused by empty buffer
+	Rebuffers uint32
+	// RebufferTime is a total duration of interruptions including the current one
+	RebufferTime time.Duration
+}
+
+// Buffer simulates player buffer which is filled by received media and drained by playback in real time
+type Buffer struct {
+	startBuffer time.Duration
+	bitrate     float64
+	startTime   time.Time
+	last        time.Time
+	pending     uint64 // bytes received before bitrate is known
+	level       time.Duration
+	started     bool
+	playing     bool
+	stallStart  time.Time
+	stats       Stats
+}
+
+// NewBuffer creates empty buffer. Simulation starts at now, bitrate may be zero if it is not known yet
+func NewBuffer(now time.Time, bitrate float64, startBuffer time.Duration) *Buffer {
+	return &Buffer{startBuffer: startBuffer, bitrate: bitrate, startTime: now, last: now}
+}
+
+// SetBitrate sets nominal bitrate of the stream if it has not been set yet
+func (b *Buffer) SetBitrate(bps float64) {
+	if b.bitrate != 0 || bps <= 0 {
+		return
+	}
+	b.bitrate = bps
+}
+
+// Feed adds received media to the buffer
+func (b *Buffer) Feed(now time.Time, bytes int) {
+	b.advance(now)
+	if b.bitrate == 0 {
+		b.pending += uint64(bytes)
+		return
+	}
+	b.level += b.mediaDuration(b.pending + uint64(bytes))
+	b.pending = 0
+
+	if !b.playing && b.level >= b.startBuffer {
+		b.playing = true
+		if !b.started {
+			b.started = true
+			b.stats.StartupDelay = now.Sub(b.startTime)
+		} else {
+			b.stats.RebufferTime += now.Sub(b.stallStart)
+		}
+	}
+}
+
+// Stats returns state of playback at the moment
+func (b *Buffer) Stats(now time.Time) Stats {
+	b.advance(now)
+	stats := b.stats
+	stats.Bitrate = b.bitrate
+	stats.Playing = b.playing
+	stats.BufferLevel = b.level
+	if b.started && !b.playing {
+		stats.RebufferTime += now.Sub(b.stallStart)
+	}
+	return stats
+}
+
+// advance drains buffer by playback since the last update
+func (b *Buffer) advance(now time.Time) {
+	elapsed := now.Sub(b.last)
+	b.last = now
+	if !b.playing || elapsed <= 0 {
+		return
+	}
+
+	if elapsed < b.level {
+		b.level -= elapsed
+		return
+	}
+
+	// buffer has been emptied in the middle of the interval
+	b.stallStart = now.Add(b.level - elapsed)
+	b.level = 0
+	b.playing = false
+	b.stats.Rebuffers++
+}
+
+func (b *Buffer) mediaDuration(bytes uint64) time.Duration {
+	return time.Duration(float64(bytes) * 8 / b.bitrate * float64(time.Second))
+}
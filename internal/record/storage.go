@@ -0,0 +1,229 @@
+package record
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const fileTimeLayout = "20060102-150405.000"
+
+// maxFileSuffix limits attempts to find unused name of the file created at the same time
+const maxFileSuffix = 1000
+
+// ErrQuotaExceeded is returned when data cannot be written without exceeding total size limit
+var ErrQuotaExceeded = errors.New("recording quota exceeded")
+
+// Settings of stream recording
+type Settings struct {
+	// Dir is a root directory of records. Each recorder writes files to its own subdirectory
+	Dir string
+
+	// MaxFileSize is a size (bytes) which causes switching to the next file, unlimited if zero
+	MaxFileSize uint64
+
+	// MaxFileDuration is a duration of writing which causes switching to the next file, unlimited if zero
+	MaxFileDuration time.Duration
+
+	// MaxTotalSize is a limit of total size (bytes) of files written by all recorders, unlimited if zero.
+	// The oldest finished files are removed to fit the limit
+	MaxTotalSize uint64
+}
+
+type file struct {
+	path string
+	size uint64
+}
+
+// Storage accounts disk usage of all recorders
+type Storage struct {
+	settings Settings
+
+	mutex    sync.Mutex
+	total    uint64
+	finished []file // oldest first
+}
+
+// NewStorage creates root directory of records
+func NewStorage(settings Settings) (*Storage, error) {
+	if err := os.MkdirAll(settings.Dir, 0755); err != nil {
+		return nil, fmt.Errorf("cannot create records directory: %w", err)
+	}
+	return &Storage{settings: settings}, nil
+}
+
+// NewRecorder creates recorder which writes files to the subdirectory of the storage
+func (s *Storage) NewRecorder(name string) *Recorder {
+	return &Recorder{storage: s, dir: filepath.Join(s.settings.Dir, name)}
+}
+
+// Save writes data to the new file of the subdirectory. The file is accounted as finished one
+func (s *Storage) Save(name, prefix string, data []byte) (string, error) {
+	if err := s.reserve(uint64(len(data))); err != nil {
+		return "", err
+	}
+
+	dir := filepath.Join(s.settings.Dir, name)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		s.release(uint64(len(data)))
+		return "", fmt.Errorf("cannot create record directory: %w", err)
+	}
+	f, path, err := createFile(dir, prefix+"-"+time.Now().Format(fileTimeLayout))
+	if err != nil {
+		s.release(uint64(len(data)))
+		return "", err
+	}
+	_, err = f.Write(data)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(path)
+		s.release(uint64(len(data)))
+		return "", err
+	}
+	s.finish(file{path: path, size: uint64(len(data))})
+	return path, nil
+}
+
+// reserve accounts n bytes to be written. Finished files are removed if the quota is exceeded
+func (s *Storage) reserve(n uint64) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	limit := s.settings.MaxTotalSize
+	for limit != 0 && s.total+n > limit && len(s.finished) != 0 {
+		f := s.finished[0]
+		s.finished = s.finished[1:]
+		s.total -= f.size
+		if err := os.Remove(f.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("cannot remove old record: %w", err)
+		}
+	}
+	if limit != 0 && s.total+n > limit {
+		return ErrQuotaExceeded
+	}
+	s.total += n
+	return nil
+}
+
+// release returns reserved bytes which have not been written
+func (s *Storage) release(n uint64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.total -= n
+}
+
+func (s *Storage) finish(f file) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.finished = append(s.finished, f)
+}
+
+// Recorder writes stream to the sequence of files
+type Recorder struct {
+	storage *Storage
+	dir     string
+
+	mutex   sync.Mutex
+	file    *os.File
+	path    string
+	size    uint64
+	opened  time.Time
+	written uint64
+}
+
+// Write appends data to the current file. The next file is started if the current one exceeds limits
+func (r *Recorder) Write(now time.Time, data []byte) error {
+	if len(data) == 0 {
+		return nil
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	settings := &r.storage.settings
+	if r.file != nil && (settings.MaxFileSize != 0 && r.size+uint64(len(data)) > settings.MaxFileSize ||
+		settings.MaxFileDuration != 0 && now.Sub(r.opened) >= settings.MaxFileDuration) {
+		if err := r.close(); err != nil {
+			return err
+		}
+	}
+
+	if err := r.storage.reserve(uint64(len(data))); err != nil {
+		return err
+	}
+
+	if r.file == nil {
+		if err := r.open(now); err != nil {
+			r.storage.release(uint64(len(data)))
+			return err
+		}
+	}
+
+	n, err := r.file.Write(data)
+	r.size += uint64(n)
+	r.written += uint64(n)
+	if n != len(data) {
+		r.storage.release(uint64(len(data) - n))
+	}
+	return err
+}
+
+// Written returns total bytes written by recorder
+func (r *Recorder) Written() uint64 {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.written
+}
+
+// Close finishes the current file. Next write starts the new one
+func (r *Recorder) Close() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.close()
+}
+
+func (r *Recorder) open(now time.Time) error {
+	if err := os.MkdirAll(r.dir, 0755); err != nil {
+		return fmt.Errorf("cannot create record directory: %w", err)
+	}
+	f, path, err := createFile(r.dir, now.Format(fileTimeLayout))
+	if err != nil {
+		return err
+	}
+	r.file, r.path, r.size, r.opened = f, path, 0, now
+	return nil
+}
+
+func (r *Recorder) close() error {
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.storage.finish(file{path: r.path, size: r.size})
+	r.file = nil
+	return err
+}
+
+// createFile creates new file of the directory. Counter suffix is added to the name if the file exists already,
+// e.g. files are rotated within the same millisecond
+func createFile(dir, name string) (*os.File, string, error) {
+	for i := 0; i < maxFileSuffix; i++ {
+		path := filepath.Join(dir, name+".bin")
+		if i != 0 {
+			path = filepath.Join(dir, fmt.Sprintf("%s-%d.bin", name, i))
+		}
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if err == nil {
+			return f, path, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, "", fmt.Errorf("cannot create record: %w", err)
+		}
+	}
+	return nil, "", fmt.Errorf("cannot create record: too many files named %s", name)
+}
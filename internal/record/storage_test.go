@@ -0,0 +1,117 @@
+package record
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+)
+
+// files returns contents of files of the directory by name
+func files(t *testing.T, dir string) map[string]string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res := make(map[string]string, len(entries))
+	for _, e := range entries {
+		data, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		res[e.Name()] = string(data)
+	}
+	return res
+}
+
+func TestRecorderRotation(t *testing.T) {
+	now := time.Date(2022, 5, 12, 19, 26, 7, 0, time.Local)
+	name := now.Format(fileTimeLayout)
+
+	tests := []struct {
+		name     string
+		existing map[string]string // files left from previous run
+		chunks   []string
+		files    map[string]string
+	}{
+		{
+			name:   "single file",
+			chunks: []string{"ab"},
+			files:  map[string]string{name + ".bin": "ab"},
+		},
+		{
+			name:   "rotated twice within timestamp",
+			chunks: []string{"aaaa", "bbbb", "cccc"},
+			files:  map[string]string{name + ".bin": "aaaa", name + "-1.bin": "bbbb", name + "-2.bin": "cccc"},
+		},
+		{
+			name:     "existing file is not overwritten",
+			existing: map[string]string{name + ".bin": "old"},
+			chunks:   []string{"new"},
+			files:    map[string]string{name + ".bin": "old", name + "-1.bin": "new"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, err := NewStorage(Settings{Dir: t.TempDir(), MaxFileSize: 4})
+			if err != nil {
+				t.Fatal(err)
+			}
+			r := s.NewRecorder("task-1")
+			dir := filepath.Join(s.settings.Dir, "task-1")
+			if err = os.MkdirAll(dir, 0755); err != nil {
+				t.Fatal(err)
+			}
+			for file, data := range test.existing {
+				if err = os.WriteFile(filepath.Join(dir, file), []byte(data), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			for _, chunk := range test.chunks {
+				if err = r.Write(now, []byte(chunk)); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err = r.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			got := files(t, dir)
+			if len(got) != len(test.files) {
+				t.Errorf("got files %v, want %v", got, test.files)
+			}
+			for file, data := range test.files {
+				if got[file] != data {
+					t.Errorf("file %s: got %q, want %q", file, got[file], data)
+				}
+			}
+		})
+	}
+}
+
+func TestStorageSave(t *testing.T) {
+	s, err := NewStorage(Settings{Dir: t.TempDir()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// samples saved within the same millisecond must not overwrite each other
+	for _, data := range []string{"first", "second", "third"} {
+		if _, err = s.Save("task-1", "sample", []byte(data)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got := files(t, filepath.Join(s.settings.Dir, "task-1"))
+	var contents []string
+	for _, data := range got {
+		contents = append(contents, data)
+	}
+	sort.Strings(contents)
+	if len(contents) != 3 || contents[0] != "first" || contents[1] != "second" || contents[2] != "third" {
+		t.Errorf("got files %v", got)
+	}
+}
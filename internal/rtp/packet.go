@@ -0,0 +1,67 @@
+package rtp
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+const (
+	headerSize = 12
+	version    = 2
+)
+
+// Packet is a parsed RTP packet (RFC 3550)
+type Packet struct {
+	PayloadType    uint8
+	SequenceNumber uint16
+	Timestamp      uint32
+	SSRC           uint32
+	Payload        []byte
+}
+
+// Parse decodes RTP packet. Payload refers to the data slice
+func Parse(data []byte) (*Packet, error) {
+	if len(data) < headerSize {
+		return nil, errors.New("packet is too short")
+	}
+	if v := data[0] >> 6; v != version {
+		return nil, fmt.Errorf("unsupported RTP version: %d", v)
+	}
+
+	p := &Packet{
+		PayloadType:    data[1] & 0x7f,
+		SequenceNumber: binary.BigEndian.Uint16(data[2:4]),
+		Timestamp:      binary.BigEndian.Uint32(data[4:8]),
+		SSRC:           binary.BigEndian.Uint32(data[8:12]),
+	}
+
+	offset := headerSize + 4*int(data[0]&0x0f)
+	if data[0]&0x10 != 0 {
+		if len(data) < offset+4 {
+			return nil, errors.New("header extension is truncated")
+		}
+		offset += 4 + 4*int(binary.BigEndian.Uint16(data[offset+2:offset+4]))
+	}
+
+	end := len(data)
+	if data[0]&0x20 != 0 {
+		end -= int(data[end-1])
+	}
+	if offset > end {
+		return nil, errors.New("packet is truncated")
+	}
+
+	p.Payload = data[offset:end]
+	return p, nil
+}
+
+// Gap returns count of packets lost between the previous and the current sequence numbers.
+// Reordered and duplicated packets are not considered as losses
+func Gap(prev, cur uint16) int {
+	diff := cur - prev - 1
+	if diff >= 0x8000 {
+		return 0
+	}
+	return int(diff)
+}
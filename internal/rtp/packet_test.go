@@ -0,0 +1,89 @@
+package rtp
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestGap(t *testing.T) {
+	tests := []struct {
+		name      string
+		prev, cur uint16
+		lost      int
+	}{
+		{name: "next", prev: 10, cur: 11, lost: 0},
+		{name: "gap", prev: 10, cur: 14, lost: 3},
+		{name: "wraparound", prev: 65535, cur: 0, lost: 0},
+		{name: "gap over wraparound", prev: 65534, cur: 2, lost: 3},
+		{name: "duplicate", prev: 10, cur: 10, lost: 0},
+		{name: "reordered", prev: 10, cur: 9, lost: 0},
+		{name: "reordered over wraparound", prev: 1, cur: 65535, lost: 0},
+		{name: "largest gap", prev: 0, cur: 0x8000, lost: 0x7fff},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if lost := Gap(test.prev, test.cur); lost != test.lost {
+				t.Errorf("Gap(%d, %d) = %d, want %d", test.prev, test.cur, lost, test.lost)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	header := []byte{0x80, 33, 0x12, 0x34, 0, 0, 0, 90, 0xde, 0xad, 0xbe, 0xef}
+	payload := []byte{0x47, 1, 2, 3}
+
+	tests := []struct {
+		name    string
+		packet  []byte
+		payload []byte
+		invalid bool
+	}{
+		{
+			name:    "plain",
+			packet:  append(append([]byte{}, header...), payload...),
+			payload: payload,
+		},
+		{
+			name:    "with CSRC",
+			packet:  append(append(append([]byte{0x81}, header[1:]...), 0, 0, 0, 1), payload...),
+			payload: payload,
+		},
+		{
+			name:    "with extension",
+			packet:  append(append(append([]byte{0x90}, header[1:]...), 0xbe, 0xde, 0, 1, 9, 9, 9, 9), payload...),
+			payload: payload,
+		},
+		{
+			name:    "with padding",
+			packet:  append(append(append([]byte{0xa0}, header[1:]...), payload...), 0, 0, 3),
+			payload: payload,
+		},
+		{name: "too short", packet: header[:11], invalid: true},
+		{name: "wrong version", packet: append([]byte{0x40}, header[1:]...), invalid: true},
+		{name: "truncated extension", packet: append([]byte{0x90}, header[1:]...), invalid: true},
+		{name: "truncated CSRC", packet: append([]byte{0x82}, header[1:]...), invalid: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p, err := Parse(test.packet)
+			if test.invalid {
+				if err == nil {
+					t.Error("error expected")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if p.PayloadType != 33 || p.SequenceNumber != 0x1234 || p.Timestamp != 90 || p.SSRC != 0xdeadbeef {
+				t.Errorf("wrong header: %+v", p)
+			}
+			if !bytes.Equal(p.Payload, test.payload) {
+				t.Errorf("got payload %v, want %v", p.Payload, test.payload)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package rtp
+
+// sequenceWindow is a count of the latest sequence numbers remembered to detect late and duplicated packets
+const sequenceWindow = 64
+
+// Order is a position of received packet in the sequence
+type Order int
+
+const (
+	// InOrder packet is ahead of all received ones
+	InOrder Order = iota
+
+	// Late packet is behind the latest received one, e.g. reordered by network
+	Late
+
+	// Duplicate packet has been received already
+	Duplicate
+)
+
+// Sequence tracks sequence numbers of received packets. The latest number is advanced only by packets which are
+// ahead in 16-bit serial number terms, so reordered packets are not accounted as gaps twice. Late packet is not
+// subtracted from the gap it has been accounted in, it is reported as late instead
+type Sequence struct {
+	started bool
+	last    uint16
+	window  uint64 // bit i is set if packet last-i has been received
+}
+
+// Push accounts sequence number of the received packet. It returns count of packets lost before it
+func (s *Sequence) Push(seq uint16) (lost int, order Order) {
+	if !s.started {
+		s.started = true
+		s.last, s.window = seq, 1
+		return 0, InOrder
+	}
+
+	if ahead := seq - s.last; ahead != 0 && ahead < 0x8000 {
+		if ahead < sequenceWindow {
+			s.window = s.window<<ahead | 1
+		} else {
+			s.window = 1
+		}
+		lost = Gap(s.last, seq)
+		s.last = seq
+		return lost, InOrder
+	}
+
+	behind := s.last - seq
+	if behind >= sequenceWindow {
+		// too old to know whether it has been received
+		return 0, Late
+	}
+	bit := uint64(1) << behind
+	if s.window&bit != 0 {
+		return 0, Duplicate
+	}
+	s.window |= bit
+	return 0, Late
+}
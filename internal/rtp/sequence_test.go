@@ -0,0 +1,45 @@
+package rtp
+
+import "testing"
+
+func TestSequence(t *testing.T) {
+	tests := []struct {
+		name       string
+		seq        []uint16
+		lost       int
+		late       int
+		duplicates int
+	}{
+		{name: "in order", seq: []uint16{10, 11, 12, 13}},
+		{name: "gap", seq: []uint16{10, 13, 14}, lost: 2},
+		{name: "reordered", seq: []uint16{10, 12, 11, 13}, lost: 1, late: 1},
+		{name: "reordered is not a gap again", seq: []uint16{10, 13, 11, 12, 14}, lost: 2, late: 2},
+		{name: "duplicate", seq: []uint16{10, 11, 11, 12}, duplicates: 1},
+		{name: "duplicate of late packet", seq: []uint16{10, 12, 11, 11, 13}, lost: 1, late: 1, duplicates: 1},
+		{name: "wraparound", seq: []uint16{65534, 65535, 1}, lost: 1},
+		{name: "reordered over wraparound", seq: []uint16{65534, 0, 65535, 1}, lost: 1, late: 1},
+		{name: "too old packet", seq: []uint16{100, 200, 101, 101}, lost: 99, late: 2},
+		{name: "jump resets window", seq: []uint16{0, 1000, 999}, lost: 999, late: 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var s Sequence
+			lost, late, duplicates := 0, 0, 0
+			for _, seq := range test.seq {
+				n, order := s.Push(seq)
+				lost += n
+				switch order {
+				case Late:
+					late++
+				case Duplicate:
+					duplicates++
+				}
+			}
+			if lost != test.lost || late != test.late || duplicates != test.duplicates {
+				t.Errorf("got lost %d, late %d, duplicates %d, want %d, %d, %d",
+					lost, late, duplicates, test.lost, test.late, test.duplicates)
+			}
+		})
+	}
+}
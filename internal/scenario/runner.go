@@ -0,0 +1,230 @@
+package scenario
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/racoon-devel/downloader/internal/api/downloader"
+	"github.com/racoon-devel/downloader/internal/task"
+	"github.com/racoon-devel/downloader/internal/utils"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// scheduleTick is a max interval between adding or stopping batches of tasks during ramps
+const scheduleTick = time.Second
+
+// groupRun is a state of the group playing. It is not thread-safe
+type groupRun struct {
+	group  *Group
+	client downloader.DownloaderClient
+
+	ids       []uint64
+	stopped   int
+	startTime time.Time
+	endTime   time.Time
+}
+
+// Run drives the server according to the scenario timeline and prints summary to out. Tasks which are still
+// running when the context is cancelled are stopped
+func Run(ctx context.Context, client downloader.DownloaderClient, s *Scenario, out io.Writer) error {
+	log.Printf("Running scenario '%s' (%d groups, %s)", s.Name, len(s.Groups), s.Duration())
+
+	runs := make([]*groupRun, 0, len(s.Groups))
+	errCh := make(chan error, len(s.Groups))
+	wg := sync.WaitGroup{}
+	for i := range s.Groups {
+		run := &groupRun{group: &s.Groups[i], client: client}
+		runs = append(runs, run)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := run.play(ctx); err != nil {
+				errCh <- fmt.Errorf("group '%s': %w", run.group.Name, err)
+			}
+		}()
+	}
+	wg.Wait()
+	close(errCh)
+
+	// tasks must be stopped even if scenario is interrupted
+	cleanupCtx := context.Background()
+	for _, run := range runs {
+		run.stopAll(cleanupCtx)
+	}
+
+	resp, err := client.ListTasks(cleanupCtx, &emptypb.Empty{})
+	if err != nil {
+		return fmt.Errorf("get tasks failed: %w", err)
+	}
+	printSummary(out, runs, resp.Tasks)
+
+	return <-errCh
+}
+
+func (r *groupRun) play(ctx context.Context) error {
+	g := r.group
+	if !sleep(ctx, g.Start) {
+		return nil
+	}
+
+	log.Printf("[%s] Ramp-up to %d tasks during %s", g.Name, g.Concurrency, g.RampUp)
+	r.startTime = time.Now()
+	err := ramp(ctx, g.RampUp, g.Concurrency, func(target int) error {
+		return r.addTasks(ctx, target-len(r.ids))
+	})
+	if err != nil || ctx.Err() != nil {
+		return err
+	}
+
+	log.Printf("[%s] Hold %d tasks during %s", g.Name, g.Concurrency, g.Hold)
+	if !sleep(ctx, g.Hold) {
+		return nil
+	}
+
+	log.Printf("[%s] Ramp-down during %s", g.Name, g.RampDown)
+	return ramp(ctx, g.RampDown, g.Concurrency, func(target int) error {
+		return r.stopTasks(ctx, target-r.stopped)
+	})
+}
+
+// ramp calls step with the count of tasks which must be processed by the moment, until all of them are processed
+func ramp(ctx context.Context, duration time.Duration, total int, step func(target int) error) error {
+	tick := scheduleTick
+	if duration != 0 && duration/time.Duration(total) < tick {
+		tick = duration / time.Duration(total)
+	}
+	if tick < 10*time.Millisecond {
+		tick = 10 * time.Millisecond
+	}
+
+	start := time.Now()
+	for {
+		target := total
+		if elapsed := time.Since(start); elapsed < duration {
+			target = int(float64(total) * float64(elapsed) / float64(duration))
+		}
+		if err := step(target); err != nil {
+			return err
+		}
+		if target == total || !sleep(ctx, tick) {
+			return nil
+		}
+	}
+}
+
+func (r *groupRun) addTasks(ctx context.Context, count int) error {
+	if count <= 0 {
+		return nil
+	}
+
+	urls := make([]string, 0, count)
+	for i := len(r.ids); i < len(r.ids)+count; i++ {
+		urls = append(urls, r.group.URLs[i%len(r.group.URLs)])
+	}
+
+	resp, err := r.client.AddTasks(ctx, &downloader.AddTasksRequest{Urls: urls, Options: r.group.Options.proto()})
+	if err != nil {
+		return fmt.Errorf("add tasks failed: %w", err)
+	}
+
+	r.ids = append(r.ids, resp.Ids...)
+	return nil
+}
+
+// stopTasks stops the oldest running tasks
+func (r *groupRun) stopTasks(ctx context.Context, count int) error {
+	for ; count > 0 && r.stopped < len(r.ids); count-- {
+		if _, err := r.client.StopTask(ctx, &downloader.TaskRequest{Id: r.ids[r.stopped]}); err != nil {
+			return fmt.Errorf("stop task failed: %w", err)
+		}
+		r.stopped++
+	}
+	if r.stopped == len(r.ids) && r.endTime.IsZero() {
+		r.endTime = time.Now()
+	}
+	return nil
+}
+
+func (r *groupRun) stopAll(ctx context.Context) {
+	if err := r.stopTasks(ctx, len(r.ids)); err != nil {
+		log.Printf("[%s] Cannot stop tasks: %s", r.group.Name, err)
+	}
+}
+
+func printSummary(w io.Writer, runs []*groupRun, tasks []*downloader.TaskInfo) {
+	byID := make(map[uint64]*downloader.TaskInfo, len(tasks))
+	for _, t := range tasks {
+		byID[t.Id] = t
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "GROUP\tTASKS\tDURATION\tRECEIVED\tAVG BITRATE\tRECONNECTS\tSTALLS\tFAILED")
+	for _, r := range runs {
+		var bytes uint64
+		var reconnects, stalls uint32
+		failed := make(map[string]uint32)
+		for _, id := range r.ids {
+			t, ok := byID[id]
+			if !ok {
+				continue
+			}
+			bytes += t.BytesReceived
+			reconnects += t.Reconnects
+			stalls += t.Stalls
+			if t.Status == task.StatusError.String() {
+				failed[t.FailureReason]++
+			}
+		}
+
+		duration := time.Duration(0)
+		if !r.startTime.IsZero() {
+			duration = r.endTime.Sub(r.startTime)
+		}
+		bitrate := 0.0
+		if duration > 0 {
+			bitrate = float64(bytes*8) / duration.Seconds()
+		}
+
+		_, _ = fmt.Fprintf(tw, "%s\t%d\t%s\t%s\t%s\t%d\t%d\t%s\n", r.group.Name, len(r.ids), duration.Round(time.Second),
+			utils.FormatBytes(bytes), utils.FormatBitrate(bitrate), reconnects, stalls, formatReasons(failed))
+	}
+	_ = tw.Flush()
+}
+
+func formatReasons(reasons map[string]uint32) string {
+	if len(reasons) == 0 {
+		return "-"
+	}
+	keys := make([]string, 0, len(reasons))
+	for k := range reasons {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	out := ""
+	for _, k := range keys {
+		if out != "" {
+			out += ", "
+		}
+		out += fmt.Sprintf("%s: %d", k, reasons[k])
+	}
+	return out
+}
+
+// sleep waits for the duration and returns false if the context is cancelled
+func sleep(ctx context.Context, d time.Duration) bool {
+	if d <= 0 {
+		return ctx.Err() == nil
+	}
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(d):
+		return true
+	}
+}
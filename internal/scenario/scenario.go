@@ -0,0 +1,201 @@
+package scenario
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/racoon-devel/downloader/internal/api/downloader"
+	"github.com/racoon-devel/downloader/internal/utils"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"gopkg.in/yaml.v3"
+)
+
+// Scenario is a declarative description of load
+type Scenario struct {
+	Name   string  `yaml:"name"`
+	Groups []Group `yaml:"groups"`
+}
+
+// Group is a set of tasks which share URLs, schedule and options. Timeline of the group:
+// start delay, ramp-up to the concurrency, hold, ramp-down to zero
+type Group struct {
+	Name string   `yaml:"name"`
+	URLs []string `yaml:"urls"`
+
+	// Concurrency is a count of simultaneous tasks, URLs are distributed between them in round-robin manner.
+	// It is a count of URLs if unset
+	Concurrency int `yaml:"concurrency"`
+
+	Start    time.Duration `yaml:"start"`
+	RampUp   time.Duration `yaml:"ramp_up"`
+	Hold     time.Duration `yaml:"hold"`
+	RampDown time.Duration `yaml:"ramp_down"`
+
+	Options Options `yaml:"options"`
+}
+
+// Options override server settings for tasks of the group
+type Options struct {
+	Type    string `yaml:"type"`
+	Variant string `yaml:"variant"`
+	Analyze *bool  `yaml:"analyze"`
+	Retry   *Retry `yaml:"retry"`
+	// RateLimit is a limit of reading rate of each task, e.g. "4M"
+	RateLimit *Bitrate `yaml:"rate_limit"`
+	// Playout enables simulation of player buffer, PlayoutBitrate is a nominal bitrate of the streams
+	Playout        *bool   `yaml:"playout"`
+	PlayoutBitrate Bitrate `yaml:"playout_bitrate"`
+	// Record enables writing received media to files of server records directory
+	Record *bool `yaml:"record"`
+	// Verify enables comparing hashes of content with other tasks, Capture is a size of sample of each session
+	Verify  *bool `yaml:"verify"`
+	Capture *Size `yaml:"capture"`
+	// Headers, Method, RangeStart and Query customize HTTP requests
+	Headers    map[string]string `yaml:"headers"`
+	Method     string            `yaml:"method"`
+	RangeStart uint64            `yaml:"range_start"`
+	Query      map[string]string `yaml:"query"`
+	// IsolatedTransport gives each task own HTTP connections instead of server connection pool
+	IsolatedTransport *bool `yaml:"isolated_transport"`
+	// Sources are local IP addresses or interfaces which tasks are bound to in round-robin manner
+	Sources []string `yaml:"sources"`
+	// Resolve are host overrides in form host:port:addr[,addr], DNSServer resolves other hosts
+	Resolve   []string `yaml:"resolve"`
+	DNSServer string   `yaml:"dns_server"`
+}
+
+// Size is bytes count which can be written with suffix K, M or G
+type Size uint64
+
+func (s *Size) UnmarshalYAML(value *yaml.Node) error {
+	bytes, err := utils.ParseBytes(value.Value)
+	if err != nil {
+		return err
+	}
+	*s = Size(bytes)
+	return nil
+}
+
+// Bitrate is bits per second which can be written with suffix k, M or G
+type Bitrate float64
+
+func (b *Bitrate) UnmarshalYAML(value *yaml.Node) error {
+	bps, err := utils.ParseBitrate(value.Value)
+	if err != nil {
+		return err
+	}
+	*b = Bitrate(bps)
+	return nil
+}
+
+// Retry is a reconnect policy of tasks. Omitted fields are taken from server policy
+type Retry struct {
+	MaxAttempts    *int32        `yaml:"max_attempts"`
+	InitialBackoff time.Duration `yaml:"initial_backoff"`
+	MaxBackoff     time.Duration `yaml:"max_backoff"`
+	Multiplier     *float64      `yaml:"multiplier"`
+	Jitter         *float64      `yaml:"jitter"`
+	RetryOn        []uint32      `yaml:"retry_on"`
+}
+
+// Load reads scenario from YAML file
+func Load(path string) (*Scenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Scenario{}
+	if err = yaml.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("parse scenario failed: %w", err)
+	}
+	if err = s.validate(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *Scenario) validate() error {
+	if len(s.Groups) == 0 {
+		return errors.New("scenario has no groups")
+	}
+	for i := range s.Groups {
+		g := &s.Groups[i]
+		if g.Name == "" {
+			g.Name = fmt.Sprintf("group%d", i+1)
+		}
+		if len(g.URLs) == 0 {
+			return fmt.Errorf("group '%s' has no URLs", g.Name)
+		}
+		if g.Concurrency == 0 {
+			g.Concurrency = len(g.URLs)
+		}
+		if g.Concurrency < 0 {
+			return fmt.Errorf("group '%s': concurrency must be positive", g.Name)
+		}
+		if g.Start < 0 || g.RampUp < 0 || g.Hold < 0 || g.RampDown < 0 {
+			return fmt.Errorf("group '%s': durations must not be negative", g.Name)
+		}
+	}
+	return nil
+}
+
+// Duration returns time from the start of the scenario to the end of the last group
+func (s *Scenario) Duration() time.Duration {
+	var d time.Duration
+	for _, g := range s.Groups {
+		if end := g.Start + g.RampUp + g.Hold + g.RampDown; end > d {
+			d = end
+		}
+	}
+	return d
+}
+
+func (o *Options) proto() *downloader.TaskOptions {
+	options := &downloader.TaskOptions{
+		Type:              o.Type,
+		Variant:           o.Variant,
+		Analyze:           o.Analyze,
+		Playout:           o.Playout,
+		PlayoutBitrate:    float64(o.PlayoutBitrate),
+		Record:            o.Record,
+		Verify:            o.Verify,
+		Method:            o.Method,
+		RangeStart:        o.RangeStart,
+		Query:             o.Query,
+		IsolatedTransport: o.IsolatedTransport,
+		Sources:           o.Sources,
+		Resolve:           o.Resolve,
+		DnsServer:         o.DNSServer,
+	}
+	for name, value := range o.Headers {
+		options.Headers = append(options.Headers, &downloader.Header{Name: name, Value: value})
+	}
+	if o.Capture != nil {
+		capture := uint64(*o.Capture)
+		options.Capture = &capture
+	}
+	if o.RateLimit != nil {
+		rateLimit := float64(*o.RateLimit)
+		options.RateLimit = &rateLimit
+	}
+	if o.Retry != nil {
+		options.Retry = &downloader.RetryPolicy{
+			MaxAttempts: o.Retry.MaxAttempts,
+			Multiplier:  o.Retry.Multiplier,
+			Jitter:      o.Retry.Jitter,
+		}
+		if o.Retry.RetryOn != nil {
+			options.Retry.RetryOnStatus = &downloader.StatusCodes{Codes: o.Retry.RetryOn}
+		}
+		if o.Retry.InitialBackoff != 0 {
+			options.Retry.InitialBackoff = durationpb.New(o.Retry.InitialBackoff)
+		}
+		if o.Retry.MaxBackoff != 0 {
+			options.Retry.MaxBackoff = durationpb.New(o.Retry.MaxBackoff)
+		}
+	}
+	return options
+}
@@ -0,0 +1,122 @@
+package server
+
+import (
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/racoon-devel/downloader/internal/api/downloader"
+	"github.com/racoon-devel/downloader/internal/task"
+)
+
+// AdmissionPolicy limits rate of starting tasks to avoid thundering herd against origin
+type AdmissionPolicy struct {
+	// Rate is a count of tasks started per second, unlimited if zero
+	Rate float64
+
+	// Jitter is a random deviation of intervals between starts [0..1]
+	Jitter float64
+}
+
+func (p AdmissionPolicy) validate() error {
+	if p.Rate < 0 {
+		return errors.New("rate must not be negative")
+	}
+	if p.Jitter < 0 || p.Jitter > 1 {
+		return errors.New("jitter must be in range [0..1]")
+	}
+	return nil
+}
+
+func (p AdmissionPolicy) interval() time.Duration {
+	if p.Rate <= 0 {
+		return 0
+	}
+	interval := float64(time.Second) / p.Rate
+	if p.Jitter > 0 {
+		interval *= 1 + p.Jitter*(2*rand.Float64()-1)
+	}
+	return time.Duration(interval)
+}
+
+func admissionPolicyFromProto(p *downloader.AdmissionPolicy) AdmissionPolicy {
+	return AdmissionPolicy{Rate: p.Rate, Jitter: p.Jitter}
+}
+
+type admission struct {
+	t      *task.Task
+	policy AdmissionPolicy
+}
+
+// admissionQueue is a FIFO of tasks waiting for start. Each task is started not earlier than interval of its
+// policy after the previous one, so the rate is limited across all requests
+type admissionQueue struct {
+	mutex  sync.Mutex
+	queue  []admission
+	notify chan struct{}
+}
+
+func newAdmissionQueue() *admissionQueue {
+	return &admissionQueue{notify: make(chan struct{}, 1)}
+}
+
+func (q *admissionQueue) push(policy AdmissionPolicy, tasks ...*task.Task) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	for _, t := range tasks {
+		t.Enqueue()
+		q.queue = append(q.queue, admission{t: t, policy: policy})
+	}
+
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (q *admissionQueue) pop() (admission, bool) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	if len(q.queue) == 0 {
+		return admission{}, false
+	}
+	a := q.queue[0]
+	q.queue[0] = admission{}
+	q.queue = q.queue[1:]
+	return a, true
+}
+
+// admitTasks passes queued tasks to processing according to their admission policies
+func (s *server) admitTasks() {
+	lastStart := time.Time{}
+	for {
+		a, ok := s.admission.pop()
+		if !ok {
+			select {
+			case <-s.ctx.Done():
+				return
+			case <-s.admission.notify:
+			}
+			continue
+		}
+
+		// stopped task is finished immediately, it must not hold back others
+		if a.t.Status() != task.StatusStopped {
+			if delay := time.Until(lastStart.Add(a.policy.interval())); delay > 0 {
+				select {
+				case <-s.ctx.Done():
+					return
+				case <-time.After(delay):
+				}
+			}
+			lastStart = time.Now()
+		}
+
+		select {
+		case <-s.ctx.Done():
+			return
+		case s.taskCh <- a.t:
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package server
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/racoon-devel/downloader/internal/task"
+)
+
+func TestAdmissionInterval(t *testing.T) {
+	tests := []struct {
+		policy   AdmissionPolicy
+		min, max time.Duration
+	}{
+		{policy: AdmissionPolicy{}, min: 0, max: 0},
+		{policy: AdmissionPolicy{Rate: 10}, min: 100 * time.Millisecond, max: 100 * time.Millisecond},
+		{policy: AdmissionPolicy{Rate: 10, Jitter: 0.5}, min: 50 * time.Millisecond, max: 150 * time.Millisecond},
+		{policy: AdmissionPolicy{Rate: 0.5, Jitter: 1}, min: 0, max: 4 * time.Second},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%+v", test.policy), func(t *testing.T) {
+			for i := 0; i < 100; i++ {
+				if interval := test.policy.interval(); interval < test.min || interval > test.max {
+					t.Fatalf("got %s, want [%s..%s]", interval, test.min, test.max)
+				}
+			}
+		})
+	}
+}
+
+func TestAdmissionPacing(t *testing.T) {
+	// tolerance is an allowed delay of starts over the expected time
+	const tolerance = 80 * time.Millisecond
+
+	tests := []struct {
+		name    string
+		policy  AdmissionPolicy
+		tasks   int
+		stopped int // index of task stopped before admission, -1 if none
+		minimum time.Duration
+	}{
+		{name: "unlimited", tasks: 3, stopped: -1},
+		{name: "paced", policy: AdmissionPolicy{Rate: 20}, tasks: 4, stopped: -1, minimum: 150 * time.Millisecond},
+		{name: "stopped task does not hold back others", policy: AdmissionPolicy{Rate: 10}, tasks: 3, stopped: 1, minimum: 100 * time.Millisecond},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := newTestServer(t, Settings{})
+			urls := make([]string, test.tasks)
+			for i := range urls {
+				urls[i] = fmt.Sprintf("fake://live/%s/%d", test.name, i)
+			}
+			tasks, err := s.newTasks(urls, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if test.stopped >= 0 {
+				tasks[test.stopped].Stop()
+			}
+
+			start := time.Now()
+			s.admission.push(test.policy, tasks...)
+
+			// starts of the tasks are observed by polling, so they are slightly late
+			started := make([]time.Time, test.tasks)
+			deadline := start.Add(5 * time.Second)
+			for pending := test.tasks; pending != 0; {
+				if time.Now().After(deadline) {
+					t.Fatal("timeout waiting for tasks start")
+				}
+				pending = 0
+				for i, url := range urls {
+					if i == test.stopped {
+						continue
+					}
+					if started[i].IsZero() {
+						if running(url) == 0 {
+							pending++
+							continue
+						}
+						started[i] = time.Now()
+					}
+				}
+				time.Sleep(time.Millisecond)
+			}
+
+			var last time.Time
+			for i, at := range started {
+				if i != test.stopped && at.After(last) {
+					last = at
+				}
+				if i == test.stopped && tasks[i].Status() != task.StatusStopped {
+					t.Errorf("stopped task is started: %s", tasks[i].Status())
+				}
+			}
+			if elapsed := last.Sub(start); elapsed < test.minimum || elapsed > test.minimum+tolerance {
+				t.Errorf("tasks started in %s, want about %s", elapsed, test.minimum)
+			}
+		})
+	}
+}
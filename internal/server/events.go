@@ -0,0 +1,116 @@
+package server
+
+import (
+	"sync"
+
+	"github.com/racoon-devel/downloader/internal/api/downloader"
+	"github.com/racoon-devel/downloader/internal/task"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// eventBufferSize is a count of events queued for a subscriber which does not keep up
+const eventBufferSize = 1024
+
+var eventTypes = map[task.EventType]downloader.Event_Type{
+	task.EventAdded:        downloader.Event_ADDED,
+	task.EventConnecting:   downloader.Event_CONNECTING,
+	task.EventConnected:    downloader.Event_CONNECTED,
+	task.EventFirstByte:    downloader.Event_FIRST_BYTE,
+	task.EventStall:        downloader.Event_STALL,
+	task.EventTimeout:      downloader.Event_TIMEOUT,
+	task.EventError:        downloader.Event_ERROR,
+	task.EventReconnecting: downloader.Event_RECONNECTING,
+	task.EventStopped:      downloader.Event_STOPPED,
+	task.EventFailed:       downloader.Event_FAILED,
+}
+
+type subscriber struct {
+	events chan task.Event
+	lost   bool
+}
+
+// eventHub delivers task events to subscribers. Subscriber which overflows its queue is disconnected,
+// so it never misses events silently
+type eventHub struct {
+	mutex       sync.Mutex
+	subscribers map[*subscriber]struct{}
+}
+
+func newEventHub() *eventHub {
+	return &eventHub{subscribers: make(map[*subscriber]struct{})}
+}
+
+func (h *eventHub) subscribe() *subscriber {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	sub := &subscriber{events: make(chan task.Event, eventBufferSize)}
+	h.subscribers[sub] = struct{}{}
+	return sub
+}
+
+func (h *eventHub) unsubscribe(sub *subscriber) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if _, ok := h.subscribers[sub]; ok {
+		delete(h.subscribers, sub)
+		close(sub.events)
+	}
+}
+
+func (h *eventHub) publish(e task.Event) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	for sub := range h.subscribers {
+		select {
+		case sub.events <- e:
+		default:
+			sub.lost = true
+			delete(h.subscribers, sub)
+			close(sub.events)
+		}
+	}
+}
+
+func (s *server) WatchEvents(request *downloader.WatchEventsRequest, stream downloader.Downloader_WatchEventsServer) error {
+	filter := make(map[uint64]bool, len(request.TaskIds))
+	for _, id := range request.TaskIds {
+		filter[id] = true
+	}
+
+	sub := s.events.subscribe()
+	defer s.events.unsubscribe(sub)
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-s.ctx.Done():
+			return nil
+		case e, ok := <-sub.events:
+			if !ok {
+				if sub.lost {
+					return status.Error(codes.ResourceExhausted, "events are not consumed in time")
+				}
+				return nil
+			}
+			if len(filter) != 0 && !filter[e.TaskID] {
+				continue
+			}
+			if err := stream.Send(eventToProto(e)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func eventToProto(e task.Event) *downloader.Event {
+	return &downloader.Event{
+		Type:    eventTypes[e.Type],
+		TaskId:  e.TaskID,
+		Time:    timestamppb.New(e.Time),
+		Reason:  e.Reason,
+		Message: e.Message,
+	}
+}
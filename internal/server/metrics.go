@@ -0,0 +1,181 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/racoon-devel/downloader/internal/metrics"
+	"github.com/racoon-devel/downloader/internal/task"
+)
+
+const metricsShutdownTimeout = 5 * time.Second
+
+// latencyBuckets are upper bounds (sec) of request latency histogram buckets
+var latencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+var exposedStatuses = []task.Status{
+	task.StatusConnecting,
+	task.StatusActive,
+	task.StatusError,
+	task.StatusStopped,
+	task.StatusReconnecting,
+	task.StatusQueued,
+}
+
+type serverMetrics struct {
+	registry    *metrics.Registry
+	tasks       *metrics.Vec
+	bytes       *metrics.Vec
+	bytesPerSec *metrics.Vec
+	failures    *metrics.Vec
+	reconnects  *metrics.Vec
+	stalls      *metrics.Vec
+	packets     *metrics.Vec
+	packetsLost *metrics.Vec
+	malformed   *metrics.Vec
+	reordered   *metrics.Vec
+	duplicates  *metrics.Vec
+	syncLosses  *metrics.Vec
+	ccErrors    *metrics.Vec
+	rebuffers   *metrics.Vec
+	recorded    *metrics.Vec
+	rebufTime   *metrics.Vec
+	failedTasks *metrics.Vec
+	// failedReasons are reasons ever exposed by failedTasks, so the gauge is reset when tasks recover
+	failedReasons map[string]struct{}
+	targetCount   *metrics.Vec
+	targetActual  *metrics.Vec
+	sourceTasks   *metrics.Vec
+	sourceRate    *metrics.Vec
+	sourceBytes   *metrics.Vec
+	sourceFails   *metrics.Vec
+	latency       *metrics.Histogram
+	segments      *metrics.Histogram
+	dns           *metrics.Histogram
+}
+
+func newServerMetrics() *serverMetrics {
+	r := metrics.NewRegistry()
+	return &serverMetrics{
+		registry:      r,
+		tasks:         r.NewGauge("downloader_tasks", "Count of tasks by status", "status"),
+		bytes:         r.NewCounter("downloader_received_bytes_total", "Total bytes received by all tasks"),
+		bytesPerSec:   r.NewGauge("downloader_received_bytes_per_second", "Aggregate receiving rate"),
+		failures:      r.NewCounter("downloader_session_failures_total", "Failed stream sessions by reason", "reason"),
+		reconnects:    r.NewCounter("downloader_reconnects_total", "Total reconnects of all tasks"),
+		stalls:        r.NewCounter("downloader_stalls_total", "Total stalls of segmented streams"),
+		packets:       r.NewCounter("downloader_received_packets_total", "Total datagrams received by UDP and RTP tasks"),
+		packetsLost:   r.NewCounter("downloader_lost_packets_total", "Total RTP packets detected as lost"),
+		malformed:     r.NewCounter("downloader_malformed_packets_total", "Total received datagrams which are not valid RTP packets"),
+		reordered:     r.NewCounter("downloader_reordered_packets_total", "Total RTP packets received after their successors"),
+		duplicates:    r.NewCounter("downloader_duplicate_packets_total", "Total RTP packets received twice"),
+		syncLosses:    r.NewCounter("downloader_ts_sync_losses_total", "Total losses of MPEG-TS synchronization"),
+		ccErrors:      r.NewCounter("downloader_ts_continuity_errors_total", "Total MPEG-TS continuity counter errors"),
+		recorded:      r.NewCounter("downloader_recorded_bytes_total", "Total bytes of received media written to files"),
+		rebuffers:     r.NewCounter("downloader_rebuffers_total", "Total rebuffers of simulated players"),
+		rebufTime:     r.NewCounter("downloader_rebuffer_seconds_total", "Total duration of rebuffering of simulated players"),
+		failedTasks:   r.NewGauge("downloader_failed_tasks", "Count of failed tasks by reason of the last failure", "reason"),
+		failedReasons: make(map[string]struct{}),
+		targetCount:   r.NewGauge("downloader_target_tasks", "Target count of tasks maintained by target", "target"),
+		targetActual:  r.NewGauge("downloader_target_actual_tasks", "Count of running tasks maintained by target", "target"),
+		sourceTasks:   r.NewGauge("downloader_source_tasks", "Count of tasks bound to local address", "source"),
+		sourceRate:    r.NewGauge("downloader_source_received_bytes_per_second", "Receiving rate of tasks bound to local address", "source"),
+		sourceBytes:   r.NewCounter("downloader_source_received_bytes_total", "Total bytes received by tasks bound to local address", "source"),
+		sourceFails:   r.NewCounter("downloader_source_failures_total", "Failed stream sessions of tasks bound to local address", "source"),
+		latency:       r.NewHistogram("downloader_request_latency_seconds", "Time from sending request to receiving response headers", latencyBuckets),
+		segments:      r.NewHistogram("downloader_segment_fetch_seconds", "Time of media segment fetching", latencyBuckets),
+		dns:           r.NewHistogram("downloader_dns_lookup_seconds", "Time of resolving host names", latencyBuckets),
+	}
+}
+
+func (m *serverMetrics) update(stat snapshot, samples task.Samples) {
+	for _, status := range exposedStatuses {
+		m.tasks.Set(float64(stat.byStatus[status]), status.String())
+	}
+	m.bytes.Set(float64(stat.totalBytes))
+	m.bytesPerSec.Set(stat.bytesPerSec)
+	for reason, count := range stat.failures {
+		m.failures.Set(float64(count), reason)
+	}
+	m.reconnects.Set(float64(stat.values["reconnects"]))
+	m.stalls.Set(float64(stat.values["stalls"]))
+	m.packets.Set(float64(stat.packets))
+	m.packetsLost.Set(float64(stat.packetsLost))
+	m.malformed.Set(float64(stat.malformed))
+	m.reordered.Set(float64(stat.reordered))
+	m.duplicates.Set(float64(stat.duplicates))
+	m.syncLosses.Set(float64(stat.syncLosses))
+	m.ccErrors.Set(float64(stat.ccErrors))
+	m.recorded.Set(float64(stat.recorded))
+	m.rebuffers.Set(float64(stat.playout.rebuffers))
+	m.rebufTime.Set(stat.playout.rebufferTime.Seconds())
+	for reason := range stat.failedByReason {
+		m.failedReasons[reason] = struct{}{}
+	}
+	for reason := range m.failedReasons {
+		m.failedTasks.Set(float64(stat.failedByReason[reason]), reason)
+	}
+	m.targetCount.Reset()
+	m.targetActual.Reset()
+	for _, tg := range stat.targets {
+		id := strconv.FormatUint(tg.id, 10)
+		m.targetCount.Set(float64(tg.count), id)
+		m.targetActual.Set(float64(tg.actual), id)
+	}
+	// gauges of sources without tasks are removed, counters stay cumulative
+	m.sourceTasks.Reset()
+	m.sourceRate.Reset()
+	for _, source := range stat.sources {
+		if source.tasks != 0 {
+			m.sourceTasks.Set(float64(source.tasks), source.addr)
+			m.sourceRate.Set(source.bitrate/8, source.addr)
+		}
+		m.sourceBytes.Set(float64(source.bytes), source.addr)
+		m.sourceFails.Set(float64(source.failures), source.addr)
+	}
+	for _, latency := range samples.Requests {
+		m.latency.Observe(latency.Seconds())
+	}
+	for _, latency := range samples.Segments {
+		m.segments.Observe(latency.Seconds())
+	}
+	for _, latency := range samples.DNS {
+		m.dns.Observe(latency.Seconds())
+	}
+}
+
+// serveMetrics runs HTTP server which exposes /metrics until server context is done
+func (s *server) serveMetrics(addr string) error {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("listen metrics endpoint failed: %w", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", s.metrics.registry)
+	srv := &http.Server{Handler: mux}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		if err := srv.Serve(l); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("Metrics server failed: %s", err)
+		}
+	}()
+
+	go func() {
+		<-s.ctx.Done()
+		ctx, cancel := context.WithTimeout(context.Background(), metricsShutdownTimeout)
+		defer cancel()
+		_ = srv.Shutdown(ctx)
+	}()
+
+	log.Printf("Metrics are exposed on http://%s/metrics", l.Addr())
+	return nil
+}
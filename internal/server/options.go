@@ -0,0 +1,210 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+
+	"github.com/racoon-devel/downloader/internal/api/downloader"
+	"github.com/racoon-devel/downloader/internal/hls"
+	"github.com/racoon-devel/downloader/internal/task"
+)
+
+// applyOptions configures task according to server settings and overrides them by request options. Task is bound
+// to the next address of sources if the pool is not nil
+func (s *server) applyOptions(t *task.Task, url string, options *downloader.TaskOptions, sources *sourcePool) error {
+	t.Timeout = s.settings.Timeout
+	t.Retry = s.settings.Retry
+	t.Variant = s.settings.Variant
+	t.Analyze = s.settings.Analyze
+	t.RateLimit = s.settings.RateLimit
+	t.Playout = s.settings.Playout
+	t.PlayoutBitrate = s.settings.PlayoutBitrate
+	t.Storage = s.storage
+	t.Record = s.settings.Record
+	t.Capture = s.settings.Capture
+	if s.settings.Verify {
+		t.Contents = s.contents
+	}
+	t.VerifyBytes = s.settings.VerifyBytes
+	t.SharedLimiter = s.limiter
+	t.TLS = s.tls
+
+	kind, err := task.DetectType(url)
+	if options != nil && options.Type != "" {
+		if !task.IsRegistered(options.Type) {
+			return fmt.Errorf("unsupported stream type: %s", options.Type)
+		}
+		kind, err = options.Type, nil
+	}
+	if err != nil {
+		return err
+	}
+	t.Type = kind
+
+	if err = s.applyTransportOptions(t, options, sources); err != nil {
+		return err
+	}
+	if options == nil {
+		return nil
+	}
+
+	if options.Retry != nil {
+		t.Retry = mergeRetryPolicy(t.Retry, options.Retry)
+	}
+	if options.Variant != "" {
+		variant, err := hls.ParseVariantPolicy(options.Variant)
+		if err != nil {
+			return err
+		}
+		t.Variant = variant
+	}
+	if options.Analyze != nil {
+		t.Analyze = *options.Analyze
+	}
+	if options.Playout != nil {
+		t.Playout = *options.Playout
+	}
+	if options.PlayoutBitrate < 0 {
+		return errors.New("playout bitrate must not be negative")
+	}
+	if options.PlayoutBitrate != 0 {
+		t.PlayoutBitrate = options.PlayoutBitrate
+	}
+	if options.Record != nil {
+		if *options.Record && s.storage == nil {
+			return errors.New("recording is not configured on server")
+		}
+		t.Record = *options.Record
+	}
+	if options.Capture != nil {
+		if *options.Capture != 0 && s.storage == nil {
+			return errors.New("recording is not configured on server")
+		}
+		t.Capture = int(*options.Capture)
+	}
+	if options.Verify != nil {
+		t.Contents = nil
+		if *options.Verify {
+			t.Contents = s.contents
+		}
+	}
+	if err := applyRequestOptions(&t.Request, options); err != nil {
+		return err
+	}
+	if options.RateLimit != nil {
+		if *options.RateLimit < 0 {
+			return errors.New("rate limit must not be negative")
+		}
+		t.RateLimit = *options.RateLimit
+	}
+
+	return nil
+}
+
+// applyTransportOptions binds task to source address and chooses connection pool. Options are optional.
+// Task which resolves hosts in own way gets isolated transport, so it does not reuse connections of other tasks
+func (s *server) applyTransportOptions(t *task.Task, options *downloader.TaskOptions, sources *sourcePool) error {
+	t.TransportSettings = s.settings.Transport
+	isolated := s.settings.IsolatedTransport
+	if options != nil {
+		if options.IsolatedTransport != nil {
+			isolated = *options.IsolatedTransport
+		}
+		if err := applyResolveOptions(&t.TransportSettings, options); err != nil {
+			return err
+		}
+		if len(options.Resolve) != 0 || options.DnsServer != "" {
+			isolated = true
+		}
+	}
+
+	if sources != nil {
+		t.TransportSettings.Source = sources.pick()
+	}
+	if isolated {
+		return nil
+	}
+
+	var err error
+	t.Transport, err = s.sharedTransport(t.TransportSettings.Source)
+	return err
+}
+
+func applyResolveOptions(settings *task.TransportSettings, options *downloader.TaskOptions) error {
+	if len(options.Resolve) != 0 {
+		hosts := task.HostOverrides{}
+		for _, entry := range options.Resolve {
+			if err := hosts.Add(entry); err != nil {
+				return err
+			}
+		}
+		settings.Hosts = settings.Hosts.Merge(hosts)
+	}
+	if options.DnsServer != "" {
+		settings.DNSServer = options.DnsServer
+	}
+	return settings.Validate()
+}
+
+var tokenRe = regexp.MustCompile(`^[!#$%&'*+\-.^_\x60|~0-9A-Za-z]+$`)
+
+func applyRequestOptions(r *task.RequestOptions, options *downloader.TaskOptions) error {
+	if options.Method != "" && !tokenRe.MatchString(options.Method) {
+		return fmt.Errorf("invalid HTTP method: %s", options.Method)
+	}
+	r.Method = options.Method
+	r.RangeStart = options.RangeStart
+
+	if len(options.Headers) != 0 {
+		r.Header = make(http.Header, len(options.Headers))
+	}
+	for _, h := range options.Headers {
+		if !tokenRe.MatchString(h.Name) {
+			return fmt.Errorf("invalid header name: %q", h.Name)
+		}
+		if err := task.ValidateTemplate(h.Value); err != nil {
+			return fmt.Errorf("header %s: %w", h.Name, err)
+		}
+		r.Header.Add(h.Name, h.Value)
+	}
+
+	if len(options.Query) != 0 {
+		r.Query = make(url.Values, len(options.Query))
+	}
+	for name, value := range options.Query {
+		if err := task.ValidateTemplate(value); err != nil {
+			return fmt.Errorf("query parameter %s: %w", name, err)
+		}
+		r.Query.Set(name, value)
+	}
+	return nil
+}
+
+// mergeRetryPolicy overrides fields of the policy which are presented in the task options
+func mergeRetryPolicy(policy task.RetryPolicy, p *downloader.RetryPolicy) task.RetryPolicy {
+	if p.MaxAttempts != nil {
+		policy.MaxAttempts = int(*p.MaxAttempts)
+	}
+	if p.InitialBackoff != nil {
+		policy.InitialBackoff = p.InitialBackoff.AsDuration()
+	}
+	if p.MaxBackoff != nil {
+		policy.MaxBackoff = p.MaxBackoff.AsDuration()
+	}
+	if p.Multiplier != nil {
+		policy.Multiplier = *p.Multiplier
+	}
+	if p.Jitter != nil {
+		policy.Jitter = *p.Jitter
+	}
+	if p.RetryOnStatus != nil {
+		policy.RetryOnStatus = make([]int, 0, len(p.RetryOnStatus.Codes))
+		for _, code := range p.RetryOnStatus.Codes {
+			policy.RetryOnStatus = append(policy.RetryOnStatus, int(code))
+		}
+	}
+	return policy
+}
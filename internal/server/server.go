@@ -2,28 +2,88 @@ package server
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
 	"log"
 	"net"
 	"os"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/racoon-devel/downloader/internal/api/downloader"
+	"github.com/racoon-devel/downloader/internal/hls"
+	"github.com/racoon-devel/downloader/internal/record"
 	"github.com/racoon-devel/downloader/internal/task"
+	"github.com/racoon-devel/downloader/internal/throttle"
 	"github.com/racoon-devel/downloader/internal/utils"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const maxTasksPerMoment = 10000
 const printStatisticInterval = 5 * time.Second
 const updateStatisticInterval = 1 * time.Second
 
+// contentRegistryCapacity is a count of the latest content hashes kept for verification
+const contentRegistryCapacity = 100000
+
 type Settings struct {
 	Ctx     context.Context
 	Timeout time.Duration
+	Retry   task.RetryPolicy
+	Variant hls.VariantPolicy
+	Analyze bool
 	Network string
 	Addr    string
+
+	// Playout enables simulation of player buffer for all tasks
+	Playout bool
+
+	// PlayoutBitrate is a nominal bitrate for playout simulation (bits per second), taken from HLS/DASH if zero
+	PlayoutBitrate float64
+
+	// TLS configures connections of HTTPS streams
+	TLS TLSSettings
+
+	// Transport tunes HTTP connections pooled by all tasks
+	Transport task.TransportSettings
+
+	// IsolatedTransport makes each task use own HTTP transport instead of the shared pool
+	IsolatedTransport bool
+
+	// Sources are local IP addresses or network interfaces which outgoing connections are bound to.
+	// Tasks are distributed between addresses in round-robin manner, address is chosen by system if empty
+	Sources []string
+
+	// Admission limits rate of starting new tasks
+	Admission AdmissionPolicy
+
+	// Record enables writing received media of all tasks to files
+	Record bool
+
+	// Storage configures writing received media to files, recording is not available if Dir is empty
+	Storage record.Settings
+
+	// Capture is a count of the first bytes of each session which are saved to Storage as a sample, disabled if zero
+	Capture int
+
+	// Verify enables comparing hashes of content received by tasks from the same URL
+	Verify bool
+
+	// VerifyBytes is a count of the first bytes of continuous stream which are hashed for verification, disabled if zero
+	VerifyBytes int
+
+	// RateLimit limits reading rate of each task (bits per second), unlimited if zero
+	RateLimit float64
+
+	// GlobalRateLimit limits total reading rate of all tasks (bits per second), unlimited if zero
+	GlobalRateLimit float64
+
+	// MetricsAddr is a TCP address of HTTP listener with Prometheus metrics. Metrics are disabled if empty
+	MetricsAddr string
 }
 
 type server struct {
@@ -33,25 +93,82 @@ type server struct {
 	ctx        context.Context
 	cancel     context.CancelFunc
 
-	timeout time.Duration
-	taskCh  chan *task.Task
-	tasks   []*task.Task
-	wg      sync.WaitGroup
+	settings Settings
+	taskCh   chan *task.Task
+	wg       sync.WaitGroup
+
+	mutex   sync.Mutex
+	tasks   map[uint64]*task.Task
+	lastID  uint64
+	retired totals // counters of removed tasks
+
+	targets      map[uint64]*target
+	lastTargetID uint64
 
-	stat statistic
+	stat      statistic
+	metrics   *serverMetrics
+	events    *eventHub
+	admission *admissionQueue
+	limiter   *throttle.Limiter // shared by all tasks
+	storage   *record.Storage
+	contents  *task.ContentRegistry
+	tls       *tls.Config
+
+	// guarded by mutex
+	sources    *sourcePool                // nil if sources are not set
+	transports map[string]*task.Transport // shared by tasks which are not isolated, by source address
+	closing    bool                       // no more task goroutines may be added to wg
 }
 
 // Run starts gRPC server which handle user requests
 func Run(settings Settings) error {
-	srv := server{grpcServer: grpc.NewServer(grpc.MaxRecvMsgSize(utils.MaxMessageSize), grpc.MaxSendMsgSize(utils.MaxMessageSize))}
+	srv, err := newServer(settings)
+	if err != nil {
+		return err
+	}
+	return srv.listenAndServe(&settings)
+}
+
+// newServer creates server by settings, it neither listens nor processes tasks yet
+func newServer(settings Settings) (*server, error) {
+	srv := &server{grpcServer: grpc.NewServer(grpc.MaxRecvMsgSize(utils.MaxMessageSize), grpc.MaxSendMsgSize(utils.MaxMessageSize))}
 	srv.ctx, srv.cancel = context.WithCancel(settings.Ctx)
 
-	srv.tasks = make([]*task.Task, 0)
+	srv.tasks = make(map[uint64]*task.Task)
+	srv.retired = newTotals()
+	srv.targets = make(map[uint64]*target)
+	srv.events = newEventHub()
+	srv.admission = newAdmissionQueue()
+	srv.contents = task.NewContentRegistry(contentRegistryCapacity)
 	srv.taskCh = make(chan *task.Task, maxTasksPerMoment)
+	if settings.GlobalRateLimit != 0 {
+		srv.limiter = throttle.NewLimiter(settings.GlobalRateLimit)
+	}
 
-	srv.timeout = settings.Timeout
+	srv.settings = settings
+	var err error
+	if srv.tls, err = settings.TLS.config(); err != nil {
+		return nil, err
+	}
+	if err = settings.Transport.Validate(); err != nil {
+		return nil, err
+	}
+	srv.transports = make(map[string]*task.Transport)
+	if len(settings.Sources) != 0 {
+		if srv.sources, err = newSourcePool(settings.Sources); err != nil {
+			return nil, err
+		}
+	}
+	if settings.Capture != 0 && settings.Storage.Dir == "" {
+		return nil, errors.New("records directory must be set to capture samples")
+	}
+	if settings.Storage.Dir != "" {
+		if srv.storage, err = record.NewStorage(settings.Storage); err != nil {
+			return nil, err
+		}
+	}
 
-	return srv.listenAndServe(&settings)
+	return srv, nil
 }
 
 func (s *server) listenAndServe(settings *Settings) error {
@@ -69,15 +186,18 @@ func (s *server) listenAndServe(settings *Settings) error {
 	}
 	log.Println("Server started")
 
+	if settings.MetricsAddr != "" {
+		s.metrics = newServerMetrics()
+		if err = s.serveMetrics(settings.MetricsAddr); err != nil {
+			_ = l.Close()
+			return err
+		}
+	}
+
 	// register callbacks for RPC server
 	downloader.RegisterDownloaderServer(s.grpcServer, s)
 
-	// start processing command channels
-	s.wg.Add(1)
-	go func() {
-		defer s.wg.Done()
-		s.processEvents()
-	}()
+	s.start()
 	defer s.wg.Wait()
 
 	// run gRPC server
@@ -88,6 +208,21 @@ func (s *server) listenAndServe(settings *Settings) error {
 	return nil
 }
 
+// start runs processing of command channels until server context is done
+func (s *server) start() {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.processEvents()
+	}()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.admitTasks()
+	}()
+}
+
 func (s *server) processEvents() {
 	prevTime := time.Now()
 	ticker := time.NewTicker(updateStatisticInterval)
@@ -96,19 +231,21 @@ func (s *server) processEvents() {
 	for {
 		select {
 		case <-s.ctx.Done():
+			s.mutex.Lock()
+			s.closing = true
+			s.mutex.Unlock()
 			s.grpcServer.Stop()
 			return
 
 		case t := <-s.taskCh:
 			if t != nil {
-				s.tasks = append(s.tasks, t)
-				t.Timeout = s.timeout
 				t.Run(&s.wg)
 			} else {
 				s.stopTasks()
 			}
 
 		case now := <-ticker.C:
+			s.maintainTargets()
 			s.updateStatistic()
 			if now.Sub(prevTime) >= printStatisticInterval {
 				s.printStatistic()
@@ -118,9 +255,152 @@ func (s *server) processEvents() {
 	}
 }
 
-func (s *server) stopTasks() {
+// newTask creates task with unique ID and registers it
+func (s *server) newTask(url string, options *downloader.TaskOptions) (*task.Task, error) {
+	tasks, err := s.newTasks([]string{url}, options)
+	if err != nil {
+		return nil, err
+	}
+	return tasks[0], nil
+}
+
+// newTasks creates tasks with unique IDs. Tasks are registered only if options are valid for all URLs
+func (s *server) newTasks(urls []string, options *downloader.TaskOptions) ([]*task.Task, error) {
+	sources, err := s.requestSources(options)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.createTasks(urls, options, sources)
+}
+
+// createTasks is newTasks which must be called with mutex held. Tasks are distributed between sources in
+// round-robin manner
+func (s *server) createTasks(urls []string, options *downloader.TaskOptions, sources *sourcePool) ([]*task.Task, error) {
+	tasks := make([]*task.Task, 0, len(urls))
+	for i, url := range urls {
+		t := task.NewTask(s.ctx, s.lastID+uint64(i)+1, url)
+		if err := s.applyOptions(t, url, options, sources); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid task options of %s: %s", url, err)
+		}
+		t.OnEvent = s.events.publish
+		tasks = append(tasks, t)
+	}
+
+	for _, t := range tasks {
+		s.lastID++
+		s.tasks[t.ID()] = t
+		s.events.publish(task.NewEvent(task.EventAdded, t.ID(), "", t.Info().URL))
+	}
+	return tasks, nil
+}
+
+// listTasks returns snapshots of all registered tasks ordered by ID
+func (s *server) listTasks() []task.Info {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	infos := make([]task.Info, 0, len(s.tasks))
 	for _, t := range s.tasks {
+		infos = append(infos, t.Info())
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].ID < infos[j].ID
+	})
+	return infos
+}
+
+// findTask looks up registered task by ID
+func (s *server) findTask(id uint64) (*task.Task, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	t, ok := s.tasks[id]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "task %d not found", id)
+	}
+	return t, nil
+}
+
+// removeTask stops task and unregisters it after completion
+func (s *server) removeTask(id uint64) error {
+	t, err := s.findTask(id)
+	if err != nil {
+		return err
+	}
+	s.retireTasks(t)
+	return nil
+}
+
+// retireTasks stops tasks, waits for their completion and moves final counters of still registered ones
+// to retired, so bytes received by the last reads are not lost
+func (s *server) retireTasks(tasks ...*task.Task) {
+	for _, t := range tasks {
 		t.Stop()
 	}
-	s.tasks = make([]*task.Task, 0)
+	for _, t := range tasks {
+		t.Wait()
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for _, t := range tasks {
+		if s.tasks[t.ID()] == t {
+			delete(s.tasks, t.ID())
+			s.retired.add(t.Info())
+		}
+	}
+}
+
+// restartTask restarts task asynchronously. The restart is tracked by server wait group, so the server
+// does not exit until the task is started again. Restart of the task which is already restarting is merged
+// with the pending one
+func (s *server) restartTask(id uint64) error {
+	s.mutex.Lock()
+	t, ok := s.tasks[id]
+	if !ok {
+		s.mutex.Unlock()
+		return status.Errorf(codes.NotFound, "task %d not found", id)
+	}
+	if s.closing {
+		s.mutex.Unlock()
+		return status.Error(codes.Unavailable, "server is shutting down")
+	}
+	if !t.BeginRestart() {
+		s.mutex.Unlock()
+		return nil
+	}
+	// processEvents is still counted in wg while closing is not set, so Wait cannot be in progress
+	s.wg.Add(1)
+	s.mutex.Unlock()
+
+	go func() {
+		defer s.wg.Done()
+		t.Wait()
+
+		// removed task must not be started again. Task stopped after BeginRestart is left stopped by FinishRestart
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		if s.tasks[id] == t {
+			t.FinishRestart(&s.wg)
+		}
+	}()
+	return nil
+}
+
+func (s *server) stopTasks() {
+	s.mutex.Lock()
+	tasks := make([]*task.Task, 0, len(s.tasks))
+	for _, t := range s.tasks {
+		tasks = append(tasks, t)
+	}
+	for _, tg := range s.targets {
+		tg.removed = true
+	}
+	s.targets = make(map[uint64]*target)
+	s.mutex.Unlock()
+
+	s.retireTasks(tasks...)
 }
@@ -0,0 +1,207 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/racoon-devel/downloader/internal/api/downloader"
+	"github.com/racoon-devel/downloader/internal/task"
+)
+
+const fakeType = "fake"
+
+// fakeSessions counts running sessions of fake streams by URL
+var fakeSessions = struct {
+	sync.Mutex
+	running map[string]int
+}{running: make(map[string]int)}
+
+// fakeStreamer behaves by URL host: "live" streams until cancelled, "fail" fails at once
+type fakeStreamer struct{}
+
+func (fakeStreamer) Receive(ctx context.Context, s *task.Session) error {
+	u, err := url.Parse(s.URL())
+	if err != nil {
+		return err
+	}
+	if u.Host == "fail" {
+		return errors.New("broken stream")
+	}
+
+	fakeSessions.Lock()
+	fakeSessions.running[s.URL()]++
+	fakeSessions.Unlock()
+	defer func() {
+		fakeSessions.Lock()
+		fakeSessions.running[s.URL()]--
+		fakeSessions.Unlock()
+	}()
+
+	s.Connected()
+	s.Received(188)
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func init() {
+	task.Register(fakeType, func() task.Streamer { return fakeStreamer{} })
+}
+
+func running(url string) int {
+	fakeSessions.Lock()
+	defer fakeSessions.Unlock()
+	return fakeSessions.running[url]
+}
+
+// newTestServer creates server which processes tasks without listening
+func newTestServer(t *testing.T, settings Settings) *server {
+	settings.Ctx = context.Background()
+	s, err := newServer(settings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.start()
+	t.Cleanup(func() {
+		s.cancel()
+		done := make(chan struct{})
+		go func() {
+			s.wg.Wait()
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Error("server is not stopped, task goroutines are leaked")
+		}
+	})
+	return s
+}
+
+// eventually waits for the condition, then checks that it is stable for a while
+func eventually(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timeout waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	time.Sleep(50 * time.Millisecond)
+	if !cond() {
+		t.Fatalf("%s is not stable", what)
+	}
+}
+
+func addTask(t *testing.T, s *server, url string) *task.Task {
+	t.Helper()
+	resp, err := s.AddTask(context.Background(), &downloader.AddTaskRequest{Url: url})
+	if err != nil {
+		t.Fatal(err)
+	}
+	found, err := s.findTask(resp.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return found
+}
+
+func isRegistered(s *server, id uint64) bool {
+	_, err := s.findTask(id)
+	return err == nil
+}
+
+func TestRestartTask(t *testing.T) {
+	tests := []struct {
+		name       string
+		restarts   int
+		then       func(s *server, id uint64) error
+		status     task.Status
+		sessions   int
+		registered bool
+	}{
+		{
+			name:       "single restart",
+			restarts:   1,
+			status:     task.StatusActive,
+			sessions:   1,
+			registered: true,
+		},
+		{
+			name:       "concurrent restarts are merged",
+			restarts:   10,
+			status:     task.StatusActive,
+			sessions:   1,
+			registered: true,
+		},
+		{
+			name:     "stop after restart",
+			restarts: 1,
+			then: func(s *server, id uint64) error {
+				_, err := s.StopTask(context.Background(), &downloader.TaskRequest{Id: id})
+				return err
+			},
+			status:     task.StatusStopped,
+			registered: true,
+		},
+		{
+			name:     "remove after restart",
+			restarts: 3,
+			then: func(s *server, id uint64) error {
+				_, err := s.RemoveTask(context.Background(), &downloader.TaskRequest{Id: id})
+				return err
+			},
+			status: task.StatusStopped,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := newTestServer(t, Settings{})
+			url := "fake://live/" + test.name
+			tk := addTask(t, s, url)
+			eventually(t, "task is started", func() bool { return running(url) == 1 })
+
+			var wg sync.WaitGroup
+			for i := 0; i < test.restarts; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					if _, err := s.RestartTask(context.Background(), &downloader.TaskRequest{Id: tk.ID()}); err != nil {
+						t.Error(err)
+					}
+				}()
+			}
+			wg.Wait()
+			if test.then != nil {
+				if err := test.then(s, tk.ID()); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			eventually(t, "restart is completed", func() bool {
+				return running(url) == test.sessions && tk.Status() == test.status
+			})
+			if registered := isRegistered(s, tk.ID()); registered != test.registered {
+				t.Errorf("registered %t, want %t", registered, test.registered)
+			}
+
+			// the task which is left running must be controllable
+			if test.sessions != 0 {
+				tk.Stop()
+				eventually(t, "task is stopped", func() bool { return running(url) == 0 })
+			}
+		})
+	}
+}
+
+func TestRestartUnknownTask(t *testing.T) {
+	s := newTestServer(t, Settings{})
+	if _, err := s.RestartTask(context.Background(), &downloader.TaskRequest{Id: 42}); err == nil {
+		t.Error("error expected")
+	}
+}
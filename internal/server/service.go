@@ -2,23 +2,165 @@ package server
 
 import (
 	"context"
+	"sort"
+	"time"
 
 	"github.com/racoon-devel/downloader/internal/api/downloader"
 	"github.com/racoon-devel/downloader/internal/task"
+	"github.com/racoon-devel/downloader/internal/ts"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
-func (s *server) AddTask(ctx context.Context, request *downloader.AddTaskRequest) (*emptypb.Empty, error) {
-	t := task.NewTask(s.ctx, request.Url)
-	s.taskCh <- t
-	return &emptypb.Empty{}, nil
+const (
+	defaultWatchInterval = time.Second
+	minWatchInterval     = 100 * time.Millisecond
+)
+
+func (s *server) AddTask(ctx context.Context, request *downloader.AddTaskRequest) (*downloader.AddTaskResponse, error) {
+	t, err := s.newTask(request.Url, request.Options)
+	if err != nil {
+		return nil, err
+	}
+	s.admission.push(s.settings.Admission, t)
+	return &downloader.AddTaskResponse{Id: t.ID()}, nil
 }
 
 func (s *server) Status(ctx context.Context, empty *emptypb.Empty) (*downloader.StatusResponse, error) {
+	return s.statusResponse(), nil
+}
+
+func (s *server) WatchStatus(request *downloader.WatchStatusRequest, stream downloader.Downloader_WatchStatusServer) error {
+	interval := defaultWatchInterval
+	if request.Interval != nil {
+		interval = request.Interval.AsDuration()
+	}
+	if interval < minWatchInterval {
+		return status.Errorf(codes.InvalidArgument, "interval must be at least %s", minWatchInterval)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		resp := downloader.WatchStatusResponse{
+			Time:   timestamppb.Now(),
+			Status: s.statusResponse(),
+			Tasks:  s.taskInfos(),
+		}
+		if err := stream.Send(&resp); err != nil {
+			return err
+		}
+
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-s.ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *server) statusResponse() *downloader.StatusResponse {
+	stat := s.stat.get()
 	resp := downloader.StatusResponse{
-		Stat: s.stat.get(),
+		Stat:           stat.values,
+		TotalBytes:     stat.totalBytes,
+		BytesPerSec:    stat.bytesPerSec,
+		TaskBitrate:    stat.bitrates,
+		TaskAnalysis:   make(map[uint64]*downloader.TSStats, len(stat.analysis)),
+		FailedByReason: stat.failedByReason,
+		Failures:       stat.failures,
+		Targets:        make([]*downloader.TargetInfo, 0, len(stat.targets)),
+		Sources:        make([]*downloader.SourceStats, 0, len(stat.sources)),
 	}
-	return &resp, nil
+	if stat.playout.tasks != 0 || stat.playout.rebuffers != 0 {
+		resp.Playout = &downloader.PlayoutSummary{
+			Tasks:           stat.playout.tasks,
+			Playing:         stat.playout.playing,
+			Rebuffers:       stat.playout.rebuffers,
+			RebufferTime:    durationpb.New(stat.playout.rebufferTime),
+			StartupDelayAvg: durationpb.New(stat.playout.startupDelayAvg),
+		}
+	}
+	for id, analysis := range stat.analysis {
+		resp.TaskAnalysis[id] = tsStatsToProto(analysis)
+	}
+	for _, tg := range stat.targets {
+		resp.Targets = append(resp.Targets, &downloader.TargetInfo{
+			Id:       tg.id,
+			Count:    uint32(tg.count),
+			Actual:   uint32(tg.actual),
+			Replaced: tg.replaced,
+			Urls:     uint32(tg.urls),
+		})
+	}
+	for _, source := range stat.sources {
+		resp.Sources = append(resp.Sources, &downloader.SourceStats{
+			Address:    source.addr,
+			Tasks:      source.tasks,
+			Active:     source.active,
+			Bitrate:    source.bitrate,
+			TotalBytes: source.bytes,
+			Failures:   source.failures,
+		})
+	}
+	return &resp
+}
+
+func (s *server) ListTasks(ctx context.Context, empty *emptypb.Empty) (*downloader.ListTasksResponse, error) {
+	return &downloader.ListTasksResponse{Tasks: s.taskInfos()}, nil
+}
+
+func (s *server) taskInfos() []*downloader.TaskInfo {
+	infos := s.listTasks()
+	tasks := make([]*downloader.TaskInfo, 0, len(infos))
+	for _, info := range infos {
+		tasks = append(tasks, taskInfoToProto(info))
+	}
+	return tasks
+}
+
+func (s *server) StopTask(ctx context.Context, request *downloader.TaskRequest) (*emptypb.Empty, error) {
+	t, err := s.findTask(request.Id)
+	if err != nil {
+		return nil, err
+	}
+	t.Stop()
+	return &emptypb.Empty{}, nil
+}
+
+func (s *server) RestartTask(ctx context.Context, request *downloader.TaskRequest) (*emptypb.Empty, error) {
+	if err := s.restartTask(request.Id); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *server) RemoveTask(ctx context.Context, request *downloader.TaskRequest) (*emptypb.Empty, error) {
+	if err := s.removeTask(request.Id); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *server) Maintain(ctx context.Context, request *downloader.MaintainRequest) (*downloader.MaintainResponse, error) {
+	id, err := s.setTarget(request)
+	if err != nil {
+		return nil, err
+	}
+	return &downloader.MaintainResponse{Id: id}, nil
+}
+
+func (s *server) StopMaintain(ctx context.Context, request *downloader.TargetRequest) (*emptypb.Empty, error) {
+	if err := s.removeTarget(request.Id); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
 }
 
 func (s *server) Stop(ctx context.Context, empty *emptypb.Empty) (*emptypb.Empty, error) {
@@ -31,10 +173,101 @@ func (s *server) Done(ctx context.Context, empty *emptypb.Empty) (*emptypb.Empty
 	return &emptypb.Empty{}, nil
 }
 
-func (s *server) AddTasks(ctx context.Context, request *downloader.AddTasksRequest) (*emptypb.Empty, error) {
-	for _, url := range request.Urls {
-		t := task.NewTask(s.ctx, url)
-		s.taskCh <- t
+func (s *server) AddTasks(ctx context.Context, request *downloader.AddTasksRequest) (*downloader.AddTasksResponse, error) {
+	policy := s.settings.Admission
+	if request.Admission != nil {
+		policy = admissionPolicyFromProto(request.Admission)
+		if err := policy.validate(); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid admission policy: %s", err)
+		}
 	}
-	return &emptypb.Empty{}, nil
+
+	tasks, err := s.newTasks(request.Urls, request.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := downloader.AddTasksResponse{
+		Ids: make([]uint64, 0, len(tasks)),
+	}
+	for _, t := range tasks {
+		resp.Ids = append(resp.Ids, t.ID())
+	}
+	s.admission.push(policy, tasks...)
+	return &resp, nil
+}
+
+func taskInfoToProto(info task.Info) *downloader.TaskInfo {
+	ti := &downloader.TaskInfo{
+		Id:               info.ID,
+		Url:              info.URL,
+		Type:             info.Type,
+		Status:           info.Status.String(),
+		BytesReceived:    info.BytesReceived,
+		LastError:        info.LastError,
+		FailureReason:    info.FailureReason,
+		Reconnects:       info.Reconnects,
+		Bitrate:          info.Bitrate,
+		Segments:         info.Segments,
+		Stalls:           info.Stalls,
+		Packets:          info.Packets,
+		PacketRate:       info.PacketRate,
+		PacketsLost:      info.PacketsLost,
+		MalformedPackets: info.Malformed,
+		ReorderedPackets: info.Reordered,
+		DuplicatePackets: info.Duplicates,
+		RecordedBytes:    info.Recorded,
+		Verified:         info.Verified,
+		Mismatches:       info.Mismatches,
+		Source:           info.Source,
+	}
+	if !info.StartTime.IsZero() {
+		ti.StartTime = timestamppb.New(info.StartTime)
+	}
+	if info.TTFB != 0 {
+		ti.Ttfb = durationpb.New(info.TTFB)
+	}
+	if info.DNSLookup != 0 {
+		ti.DnsLookup = durationpb.New(info.DNSLookup)
+	}
+	if info.SegmentLatency != 0 {
+		ti.SegmentLatency = durationpb.New(info.SegmentLatency)
+	}
+	if info.Analysis != nil {
+		ti.Analysis = tsStatsToProto(*info.Analysis)
+	}
+	if info.Playout != nil {
+		ti.Playout = &downloader.PlayoutStats{
+			Bitrate:      info.Playout.Bitrate,
+			Playing:      info.Playout.Playing,
+			BufferLevel:  durationpb.New(info.Playout.BufferLevel),
+			StartupDelay: durationpb.New(info.Playout.StartupDelay),
+			Rebuffers:    info.Playout.Rebuffers,
+			RebufferTime: durationpb.New(info.Playout.RebufferTime),
+		}
+	}
+	return ti
+}
+
+func tsStatsToProto(stats ts.Stats) *downloader.TSStats {
+	res := &downloader.TSStats{
+		Packets:          stats.Packets,
+		SyncLosses:       stats.SyncLosses,
+		ContinuityErrors: stats.ContinuityErrors,
+		PcrJitterMax:     durationpb.New(stats.PCRJitterMax),
+		PcrJitterAvg:     durationpb.New(stats.PCRJitterAvg),
+		Pids:             make([]*downloader.PIDStats, 0, len(stats.PIDs)),
+	}
+	for pid, ps := range stats.PIDs {
+		res.Pids = append(res.Pids, &downloader.PIDStats{
+			Pid:              uint32(pid),
+			Packets:          ps.Packets,
+			ContinuityErrors: ps.ContinuityErrors,
+			Bitrate:          ps.Bitrate,
+		})
+	}
+	sort.Slice(res.Pids, func(i, j int) bool {
+		return res.Pids[i].Pid < res.Pids[j].Pid
+	})
+	return res
 }
@@ -0,0 +1,158 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+
+	"github.com/racoon-devel/downloader/internal/api/downloader"
+	"github.com/racoon-devel/downloader/internal/task"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// sourcePool distributes tasks between local addresses of outgoing connections in round-robin manner
+type sourcePool struct {
+	addrs []net.IP
+	next  int
+}
+
+// newSourcePool creates pool of addresses. Each item of spec is a local IP address or a name of network interface
+// which unicast addresses are used (IPv4 if the interface has any)
+func newSourcePool(spec []string) (*sourcePool, error) {
+	p := &sourcePool{}
+	for _, item := range spec {
+		if ip := net.ParseIP(item); ip != nil {
+			if err := checkSource(ip); err != nil {
+				return nil, err
+			}
+			p.addrs = append(p.addrs, ip)
+			continue
+		}
+		addrs, err := interfaceAddrs(item)
+		if err != nil {
+			return nil, err
+		}
+		p.addrs = append(p.addrs, addrs...)
+	}
+	if len(p.addrs) == 0 {
+		return nil, errors.New("no source addresses")
+	}
+	return p, nil
+}
+
+// pick returns the next address of the pool
+func (p *sourcePool) pick() net.IP {
+	ip := p.addrs[p.next]
+	p.next = (p.next + 1) % len(p.addrs)
+	return ip
+}
+
+// checkSource verifies that sockets can be bound to the address
+func checkSource(ip net.IP) error {
+	conn, err := net.ListenPacket("udp", net.JoinHostPort(ip.String(), "0"))
+	if err != nil {
+		return fmt.Errorf("source address %s is not local: %w", ip, err)
+	}
+	return conn.Close()
+}
+
+func interfaceAddrs(name string) ([]net.IP, error) {
+	iface, err := net.InterfaceByName(name)
+	if err != nil {
+		return nil, fmt.Errorf("source is neither IP address nor interface: %s", name)
+	}
+	addrs, err := iface.Addrs()
+	if err != nil {
+		return nil, fmt.Errorf("cannot get addresses of interface %s: %w", name, err)
+	}
+
+	// addresses of one family are used, so every task can reach the same hosts
+	var v4, v6 []net.IP
+	for _, addr := range addrs {
+		ipNet, ok := addr.(*net.IPNet)
+		// link-local addresses require zone which is not kept by the pool
+		if !ok || ipNet.IP.IsLinkLocalUnicast() {
+			continue
+		}
+		if ipNet.IP.To4() != nil {
+			v4 = append(v4, ipNet.IP)
+		} else {
+			v6 = append(v6, ipNet.IP)
+		}
+	}
+	if len(v4) != 0 {
+		return v4, nil
+	}
+	if len(v6) != 0 {
+		return v6, nil
+	}
+	return nil, fmt.Errorf("interface %s has no usable addresses", name)
+}
+
+// requestSources returns pool of sources which distributes tasks of the request. Pool of server is used if the
+// request has no sources, otherwise own pool is created, so it lives as long as the request or target using it
+func (s *server) requestSources(options *downloader.TaskOptions) (*sourcePool, error) {
+	if options == nil || len(options.Sources) == 0 {
+		return s.sources, nil
+	}
+	p, err := newSourcePool(options.Sources)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid task options: %s", err)
+	}
+	return p, nil
+}
+
+// sharedTransport returns connection pool of the source address, nil source means address chosen by system.
+// Pools are created on the first use
+func (s *server) sharedTransport(source net.IP) (*task.Transport, error) {
+	var key string
+	if source != nil {
+		key = source.String()
+	}
+	if t, ok := s.transports[key]; ok {
+		return t, nil
+	}
+	settings := s.settings.Transport
+	settings.Source = source
+	t, err := task.NewTransport(settings, s.tls)
+	if err != nil {
+		return nil, err
+	}
+	s.transports[key] = t
+	return t, nil
+}
+
+// sourceInfo is statistic of tasks bound to the source address
+type sourceInfo struct {
+	addr     string
+	tasks    uint32
+	active   uint32
+	bitrate  float64
+	bytes    uint64
+	failures uint32
+}
+
+// sourceTotals accumulates cumulative counters of tasks bound to the source address
+type sourceTotals struct {
+	bytes    uint64
+	failures uint32
+}
+
+// sourceInfos merges cumulative counters with state of current tasks, the result is ordered by address
+func sourceInfos(totals map[string]sourceTotals, current map[string]*sourceInfo) []sourceInfo {
+	infos := make([]sourceInfo, 0, len(totals))
+	for addr, total := range totals {
+		info := sourceInfo{addr: addr}
+		if c, ok := current[addr]; ok {
+			info = *c
+		}
+		info.bytes, info.failures = total.bytes, total.failures
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].addr < infos[j].addr
+	})
+	return infos
+}
@@ -0,0 +1,100 @@
+package server
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/racoon-devel/downloader/internal/api/downloader"
+	"github.com/racoon-devel/downloader/internal/task"
+)
+
+func sourcesOf(tasks []*task.Task) []string {
+	sources := make([]string, 0, len(tasks))
+	for _, t := range tasks {
+		sources = append(sources, t.TransportSettings.Source.String())
+	}
+	return sources
+}
+
+func TestRequestSources(t *testing.T) {
+	options := &downloader.TaskOptions{Sources: []string{"127.0.0.1", "127.0.0.2"}}
+
+	tests := []struct {
+		name     string
+		settings Settings
+		options  *downloader.TaskOptions
+		sources  [][]string // by request
+	}{
+		{
+			name:    "sources of request are distributed within request",
+			options: options,
+			sources: [][]string{{"127.0.0.1", "127.0.0.2", "127.0.0.1"}, {"127.0.0.1", "127.0.0.2", "127.0.0.1"}},
+		},
+		{
+			name:     "sources of server are distributed across requests",
+			settings: Settings{Sources: []string{"127.0.0.1", "127.0.0.2"}},
+			sources:  [][]string{{"127.0.0.1", "127.0.0.2", "127.0.0.1"}, {"127.0.0.2", "127.0.0.1", "127.0.0.2"}},
+		},
+		{
+			name:    "no sources",
+			sources: [][]string{{"<nil>", "<nil>", "<nil>"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := newTestServer(t, test.settings)
+			for i, expected := range test.sources {
+				tasks, err := s.newTasks([]string{"fake://live/1", "fake://live/2", "fake://live/3"}, test.options)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got := sourcesOf(tasks); !reflect.DeepEqual(got, expected) {
+					t.Errorf("request %d: got %v, want %v", i, got, expected)
+				}
+			}
+		})
+	}
+}
+
+func TestTargetSources(t *testing.T) {
+	s := newTestServer(t, Settings{})
+	resp, err := s.Maintain(context.Background(), &downloader.MaintainRequest{
+		Urls:    []string{"fake://live/target-sources"},
+		Count:   2,
+		Options: &downloader.TaskOptions{Sources: []string{"127.0.0.1", "127.0.0.2"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// replacing task continues round-robin of the target
+	s.mutex.Lock()
+	stopped := s.targets[resp.Id].tasks[0]
+	s.mutex.Unlock()
+	stopped.Stop()
+	eventually(t, "task is stopped", func() bool { return stopped.Status() == task.StatusStopped })
+	s.maintainTargets()
+
+	s.mutex.Lock()
+	got := sourcesOf(s.targets[resp.Id].tasks)
+	s.mutex.Unlock()
+	if expected := []string{"127.0.0.2", "127.0.0.1"}; !reflect.DeepEqual(got, expected) {
+		t.Errorf("got %v, want %v", got, expected)
+	}
+}
+
+func TestInvalidSources(t *testing.T) {
+	s := newTestServer(t, Settings{})
+	options := &downloader.TaskOptions{Sources: []string{"no-such-interface"}}
+	if _, err := s.AddTasks(context.Background(), &downloader.AddTasksRequest{Urls: []string{"fake://live/1"}, Options: options}); err == nil {
+		t.Error("error expected")
+	}
+	if _, err := s.Maintain(context.Background(), &downloader.MaintainRequest{Urls: []string{"fake://live/1"}, Count: 1, Options: options}); err == nil {
+		t.Error("error expected")
+	}
+	if infos := s.targetInfos(); len(infos) != 0 {
+		t.Errorf("invalid target is registered: %+v", infos)
+	}
+}
@@ -2,10 +2,14 @@ package server
 
 import (
 	"fmt"
-	"github.com/racoon-devel/downloader/internal/task"
 	"log"
 	"sort"
 	"sync"
+	"time"
+
+	"github.com/racoon-devel/downloader/internal/task"
+	"github.com/racoon-devel/downloader/internal/ts"
+	"github.com/racoon-devel/downloader/internal/utils"
 )
 
 // StatDictionary is a type of collection which consists of statistic variables
@@ -25,46 +29,260 @@ func (d StatDictionary) String() string {
 	return out
 }
 
+// totals accumulates cumulative counters of tasks
+type totals struct {
+	bytes      uint64
+	reconnects uint32
+	stalls     uint32
+	packets    uint64
+	lost       uint64
+	malformed  uint64
+	reordered  uint64
+	duplicates uint64
+	syncLosses uint64
+	ccErrors   uint64
+	rebuffers  uint32
+	rebufTime  time.Duration
+	recorded   uint64
+	mismatches uint32
+	failures   map[string]uint32
+	sources    map[string]sourceTotals
+}
+
+func newTotals() totals {
+	return totals{failures: make(map[string]uint32), sources: make(map[string]sourceTotals)}
+}
+
+func (t *totals) add(info task.Info) {
+	t.bytes += info.BytesReceived
+	t.reconnects += info.Reconnects
+	t.stalls += info.Stalls
+	t.packets += info.Packets
+	t.lost += info.PacketsLost
+	t.malformed += info.Malformed
+	t.reordered += info.Reordered
+	t.duplicates += info.Duplicates
+	if info.Analysis != nil {
+		t.syncLosses += info.Analysis.SyncLosses
+		t.ccErrors += info.Analysis.ContinuityErrors
+	}
+	t.recorded += info.Recorded
+	t.mismatches += info.Mismatches
+	if info.Playout != nil {
+		t.rebuffers += info.Playout.Rebuffers
+		t.rebufTime += info.Playout.RebufferTime
+	}
+	source := t.sources[info.Source]
+	source.bytes += info.BytesReceived
+	for reason, count := range info.Failures {
+		t.failures[reason] += count
+		source.failures += count
+	}
+	if info.Source != "" {
+		t.sources[info.Source] = source
+	}
+}
+
+func (t *totals) clone() totals {
+	res := newTotals()
+	res.bytes = t.bytes
+	res.reconnects = t.reconnects
+	res.stalls = t.stalls
+	res.packets = t.packets
+	res.lost = t.lost
+	res.malformed = t.malformed
+	res.reordered = t.reordered
+	res.duplicates = t.duplicates
+	res.syncLosses = t.syncLosses
+	res.ccErrors = t.ccErrors
+	res.rebuffers = t.rebuffers
+	res.rebufTime = t.rebufTime
+	res.recorded = t.recorded
+	res.mismatches = t.mismatches
+	for reason, count := range t.failures {
+		res.failures[reason] = count
+	}
+	for addr, source := range t.sources {
+		res.sources[addr] = source
+	}
+	return res
+}
+
+// snapshot is a set of statistic values collected at the moment
+type snapshot struct {
+	values      StatDictionary
+	byStatus    map[task.Status]uint32
+	failures    map[string]uint32
+	totalBytes  uint64
+	packets     uint64
+	packetsLost uint64
+	malformed   uint64
+	reordered   uint64
+	duplicates  uint64
+	recorded    uint64
+	bytesPerSec float64
+	bitrates    map[uint64]float64
+	analysis    map[uint64]ts.Stats
+	// failedByReason is a count of failed tasks by reason of the last failure
+	failedByReason map[string]uint32
+	syncLosses     uint64
+	ccErrors       uint64
+	targets        []targetInfo
+	playout        playoutSummary
+	sources        []sourceInfo
+}
+
+// playoutSummary aggregates states of simulated players
+type playoutSummary struct {
+	tasks           uint32
+	playing         uint32
+	rebuffers       uint32
+	rebufferTime    time.Duration
+	startupDelayAvg time.Duration
+}
+
 type statistic struct {
-	mutex  sync.Mutex
-	values StatDictionary
+	mutex   sync.Mutex
+	current snapshot
 }
 
-func (s *statistic) set(values StatDictionary) {
+func (s *statistic) set(current snapshot) {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
-	s.values = values
+	s.current = current
 }
 
-func (s *statistic) get() StatDictionary {
+func (s *statistic) get() snapshot {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
-	res := StatDictionary{}
-	for k, v := range s.values {
-		res[k] = v
+	res := s.current
+	res.values = StatDictionary{}
+	for k, v := range s.current.values {
+		res.values[k] = v
+	}
+	res.bitrates = make(map[uint64]float64, len(s.current.bitrates))
+	for k, v := range s.current.bitrates {
+		res.bitrates[k] = v
 	}
+	res.failures = make(map[string]uint32, len(s.current.failures))
+	for k, v := range s.current.failures {
+		res.failures[k] = v
+	}
+	res.failedByReason = make(map[string]uint32, len(s.current.failedByReason))
+	for k, v := range s.current.failedByReason {
+		res.failedByReason[k] = v
+	}
+	res.analysis = make(map[uint64]ts.Stats, len(s.current.analysis))
+	for k, v := range s.current.analysis {
+		res.analysis[k] = v
+	}
+	res.targets = append([]targetInfo(nil), s.current.targets...)
+	res.sources = append([]sourceInfo(nil), s.current.sources...)
 	return res
 }
 
 func (s *server) updateStatistic() {
-	var active uint32
-	var failed uint32
-	var pending uint32
+	byStatus := make(map[task.Status]uint32)
+	bitrates := make(map[uint64]float64)
+	analysis := make(map[uint64]ts.Stats)
+	failedByReason := make(map[string]uint32)
+	sources := make(map[string]*sourceInfo)
+	var bitrate float64
+	var samples task.Samples
+	var playout playoutSummary
+	var startupDelay time.Duration
+	var started int
+
+	s.mutex.Lock()
+	total := s.retired.clone()
 	for _, t := range s.tasks {
-		switch t.Status() {
-		case task.StatusActive:
-			active++
-		case task.StatusError:
-			failed++
-		case task.StatusConnecting:
-			pending++
+		info := t.Info()
+		total.add(info)
+		byStatus[info.Status]++
+		if info.Status == task.StatusError {
+			failedByReason[info.FailureReason]++
+		}
+		bitrate += info.Bitrate
+		bitrates[info.ID] = info.Bitrate
+		if info.Source != "" {
+			source, ok := sources[info.Source]
+			if !ok {
+				source = &sourceInfo{addr: info.Source}
+				sources[info.Source] = source
+			}
+			source.tasks++
+			if info.Status == task.StatusActive {
+				source.active++
+			}
+			source.bitrate += info.Bitrate
+		}
+		if info.Analysis != nil {
+			analysis[info.ID] = *info.Analysis
 		}
+		if info.Playout != nil {
+			playout.tasks++
+			if info.Playout.Playing {
+				playout.playing++
+			}
+			if info.Playout.StartupDelay != 0 {
+				startupDelay += info.Playout.StartupDelay
+				started++
+			}
+		}
+		taken := t.TakeSamples()
+		samples.Requests = append(samples.Requests, taken.Requests...)
+		samples.Segments = append(samples.Segments, taken.Segments...)
+		samples.DNS = append(samples.DNS, taken.DNS...)
 	}
+	s.mutex.Unlock()
 
-	s.stat.set(StatDictionary{"active": active, "failed": failed, "pending": pending})
+	playout.rebuffers = total.rebuffers
+	playout.rebufferTime = total.rebufTime
+	if started != 0 {
+		playout.startupDelayAvg = startupDelay / time.Duration(started)
+	}
+
+	current := snapshot{
+		values: StatDictionary{
+			"active":       byStatus[task.StatusActive],
+			"failed":       byStatus[task.StatusError],
+			"pending":      byStatus[task.StatusConnecting],
+			"stopped":      byStatus[task.StatusStopped],
+			"reconnecting": byStatus[task.StatusReconnecting],
+			"queued":       byStatus[task.StatusQueued],
+			"reconnects":   total.reconnects,
+			"stalls":       total.stalls,
+			"rebuffers":    total.rebuffers,
+			"mismatches":   total.mismatches,
+		},
+		byStatus:       byStatus,
+		failures:       total.failures,
+		totalBytes:     total.bytes,
+		packets:        total.packets,
+		packetsLost:    total.lost,
+		malformed:      total.malformed,
+		reordered:      total.reordered,
+		duplicates:     total.duplicates,
+		recorded:       total.recorded,
+		bytesPerSec:    bitrate / 8,
+		bitrates:       bitrates,
+		analysis:       analysis,
+		failedByReason: failedByReason,
+		syncLosses:     total.syncLosses,
+		ccErrors:       total.ccErrors,
+		targets:        s.targetInfos(),
+		playout:        playout,
+		sources:        sourceInfos(total.sources, sources),
+	}
+	s.stat.set(current)
+
+	if s.metrics != nil {
+		s.metrics.update(current, samples)
+	}
 }
 
 func (s *server) printStatistic() {
-	log.Println("[Status]", s.stat.get())
+	stat := s.stat.get()
+	log.Printf("[Status] %s| %s, received %s", stat.values, utils.FormatBitrate(stat.bytesPerSec*8), utils.FormatBytes(stat.totalBytes))
 }
@@ -0,0 +1,205 @@
+package server
+
+import (
+	"log"
+	"math/rand"
+	"sort"
+
+	"github.com/racoon-devel/downloader/internal/api/downloader"
+	"github.com/racoon-devel/downloader/internal/task"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// target keeps the count of running tasks over URL pool. Failed and finished tasks are replaced by new ones.
+// Fields are guarded by server mutex
+type target struct {
+	id        uint64
+	urls      []string
+	count     int
+	random    bool
+	options   *downloader.TaskOptions
+	sources   *sourcePool // kept, so replacing tasks continue round-robin
+	admission AdmissionPolicy
+
+	next     int
+	tasks    []*task.Task
+	replaced uint32
+	removed  bool
+}
+
+// targetInfo is a snapshot of target state
+type targetInfo struct {
+	id       uint64
+	count    int
+	actual   int
+	replaced uint32
+	urls     int
+}
+
+func isAlive(status task.Status) bool {
+	return status != task.StatusError && status != task.StatusStopped
+}
+
+// pickURLs chooses URLs for new tasks
+func (tg *target) pickURLs(n int) []string {
+	urls := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		if tg.random {
+			urls = append(urls, tg.urls[rand.Intn(len(tg.urls))])
+		} else {
+			urls = append(urls, tg.urls[tg.next%len(tg.urls)])
+			tg.next++
+		}
+	}
+	return urls
+}
+
+// setTarget registers new target or updates existing one and brings it to the target count immediately
+func (s *server) setTarget(request *downloader.MaintainRequest) (uint64, error) {
+	if len(request.Urls) == 0 {
+		return 0, status.Error(codes.InvalidArgument, "URL pool is empty")
+	}
+
+	admission := s.settings.Admission
+	if request.Admission != nil {
+		admission = admissionPolicyFromProto(request.Admission)
+		if err := admission.validate(); err != nil {
+			return 0, status.Errorf(codes.InvalidArgument, "invalid admission policy: %s", err)
+		}
+	}
+
+	sources, err := s.requestSources(request.Options)
+	if err != nil {
+		return 0, err
+	}
+
+	s.mutex.Lock()
+	tg, ok := s.targets[request.Id]
+	if request.Id != 0 && !ok {
+		s.mutex.Unlock()
+		return 0, status.Errorf(codes.NotFound, "target %d not found", request.Id)
+	}
+	if !ok {
+		s.lastTargetID++
+		tg = &target{id: s.lastTargetID}
+		s.targets[tg.id] = tg
+	}
+	tg.urls = request.Urls
+	tg.count = int(request.Count)
+	tg.random = request.Selection == downloader.MaintainRequest_RANDOM
+	tg.options = request.Options
+	tg.sources = sources
+	tg.admission = admission
+	s.mutex.Unlock()
+
+	if err := s.maintainTarget(tg); err != nil {
+		return 0, err
+	}
+	return tg.id, nil
+}
+
+// removeTarget unregisters target and stops its tasks
+func (s *server) removeTarget(id uint64) error {
+	s.mutex.Lock()
+	tg, ok := s.targets[id]
+	if ok {
+		delete(s.targets, id)
+		tg.removed = true
+	}
+	s.mutex.Unlock()
+
+	if !ok {
+		return status.Errorf(codes.NotFound, "target %d not found", id)
+	}
+	for _, t := range tg.tasks {
+		t.Stop()
+	}
+	return nil
+}
+
+func (s *server) maintainTargets() {
+	s.mutex.Lock()
+	targets := make([]*target, 0, len(s.targets))
+	for _, tg := range s.targets {
+		targets = append(targets, tg)
+	}
+	s.mutex.Unlock()
+
+	for _, tg := range targets {
+		if err := s.maintainTarget(tg); err != nil {
+			log.Printf("[Target %d] Cannot start tasks: %s", tg.id, err)
+		}
+	}
+}
+
+// maintainTarget unregisters dead tasks of the target, stops excess ones and starts missing ones. The whole
+// update is made under mutex, so concurrent calls do not start the same missing tasks twice
+func (s *server) maintainTarget(tg *target) error {
+	s.mutex.Lock()
+	if tg.removed {
+		s.mutex.Unlock()
+		return nil
+	}
+
+	alive := tg.tasks[:0]
+	for _, t := range tg.tasks {
+		if _, registered := s.tasks[t.ID()]; !registered {
+			tg.replaced++
+			continue
+		}
+		if isAlive(t.Status()) {
+			alive = append(alive, t)
+			continue
+		}
+		delete(s.tasks, t.ID())
+		s.retired.add(t.Info())
+		tg.replaced++
+	}
+	tg.tasks = alive
+
+	// the newest tasks are excess, they are retired after stop
+	var excess []*task.Task
+	if len(tg.tasks) > tg.count {
+		excess = append(excess, tg.tasks[tg.count:]...)
+		tg.tasks = tg.tasks[:tg.count]
+	}
+
+	var tasks []*task.Task
+	var err error
+	if urls := tg.pickURLs(tg.count - len(tg.tasks)); len(urls) != 0 {
+		if tasks, err = s.createTasks(urls, tg.options, tg.sources); err == nil {
+			tg.tasks = append(tg.tasks, tasks...)
+		}
+	}
+	admission := tg.admission
+	s.mutex.Unlock()
+
+	if len(excess) != 0 {
+		s.retireTasks(excess...)
+	}
+	if len(tasks) != 0 {
+		s.admission.push(admission, tasks...)
+	}
+	return err
+}
+
+func (s *server) targetInfos() []targetInfo {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	infos := make([]targetInfo, 0, len(s.targets))
+	for _, tg := range s.targets {
+		info := targetInfo{id: tg.id, count: tg.count, replaced: tg.replaced, urls: len(tg.urls)}
+		for _, t := range tg.tasks {
+			if isAlive(t.Status()) {
+				info.actual++
+			}
+		}
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].id < infos[j].id
+	})
+	return infos
+}
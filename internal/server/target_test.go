@@ -0,0 +1,151 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/racoon-devel/downloader/internal/api/downloader"
+	"github.com/racoon-devel/downloader/internal/task"
+)
+
+func TestMaintainTarget(t *testing.T) {
+	tests := []struct {
+		name     string
+		count    uint32
+		then     func(t *testing.T, s *server, id uint64)
+		running  []int // running sessions by URL of the pool
+		replaced uint32
+		removed  bool
+	}{
+		{
+			name:    "tasks are spread over URLs",
+			count:   3,
+			running: []int{2, 1},
+		},
+		{
+			name:  "stopped task is replaced",
+			count: 3,
+			then: func(t *testing.T, s *server, id uint64) {
+				s.mutex.Lock()
+				stopped := s.targets[id].tasks[0]
+				s.mutex.Unlock()
+				stopped.Stop()
+				eventually(t, "task is stopped", func() bool { return stopped.Status() == task.StatusStopped })
+				s.maintainTargets()
+			},
+			// round robin goes on, so the replacing task takes the next URL
+			running:  []int{1, 2},
+			replaced: 1,
+		},
+		{
+			name:  "removed task is replaced",
+			count: 2,
+			then: func(t *testing.T, s *server, id uint64) {
+				s.mutex.Lock()
+				removed := s.targets[id].tasks[1]
+				s.mutex.Unlock()
+				if _, err := s.RemoveTask(context.Background(), &downloader.TaskRequest{Id: removed.ID()}); err != nil {
+					t.Fatal(err)
+				}
+				s.maintainTargets()
+			},
+			running:  []int{2, 0},
+			replaced: 1,
+		},
+		{
+			name:  "excess tasks are trimmed",
+			count: 4,
+			then: func(t *testing.T, s *server, id uint64) {
+				maintain(t, s, id, 1)
+			},
+			running: []int{1, 0},
+		},
+		{
+			name:  "missing tasks are added",
+			count: 1,
+			then: func(t *testing.T, s *server, id uint64) {
+				maintain(t, s, id, 4)
+			},
+			running: []int{2, 2},
+		},
+		{
+			name:  "removed target stops tasks",
+			count: 3,
+			then: func(t *testing.T, s *server, id uint64) {
+				if _, err := s.StopMaintain(context.Background(), &downloader.TargetRequest{Id: id}); err != nil {
+					t.Fatal(err)
+				}
+				s.maintainTargets()
+			},
+			running: []int{0, 0},
+			removed: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := newTestServer(t, Settings{})
+			urls := []string{"fake://live/" + test.name + "/0", "fake://live/" + test.name + "/1"}
+			resp, err := s.Maintain(context.Background(), &downloader.MaintainRequest{Urls: urls, Count: test.count})
+			if err != nil {
+				t.Fatal(err)
+			}
+			eventually(t, "target is reached", func() bool { return running(urls[0])+running(urls[1]) == int(test.count) })
+
+			if test.then != nil {
+				test.then(t, s, resp.Id)
+			}
+
+			eventually(t, "target is maintained", func() bool {
+				return running(urls[0]) == test.running[0] && running(urls[1]) == test.running[1]
+			})
+			infos := s.targetInfos()
+			if test.removed {
+				if len(infos) != 0 {
+					t.Errorf("removed target is reported: %+v", infos)
+				}
+				return
+			}
+			if len(infos) != 1 {
+				t.Fatalf("got %d targets, want 1", len(infos))
+			}
+			expected := targetInfo{id: resp.Id, count: test.running[0] + test.running[1], replaced: test.replaced, urls: 2}
+			expected.actual = expected.count
+			if infos[0] != expected {
+				t.Errorf("got %+v, want %+v", infos[0], expected)
+			}
+
+			// the target holds only registered tasks
+			s.mutex.Lock()
+			for _, tk := range s.targets[resp.Id].tasks {
+				if _, ok := s.tasks[tk.ID()]; !ok {
+					t.Errorf("task %d is not registered", tk.ID())
+				}
+			}
+			if len(s.tasks) != expected.count {
+				t.Errorf("got %d registered tasks, want %d", len(s.tasks), expected.count)
+			}
+			s.mutex.Unlock()
+		})
+	}
+}
+
+func TestMaintainUnknownTarget(t *testing.T) {
+	s := newTestServer(t, Settings{})
+	if _, err := s.Maintain(context.Background(), &downloader.MaintainRequest{Id: 42, Urls: []string{"fake://live/unknown"}, Count: 1}); err == nil {
+		t.Error("error expected")
+	}
+	if _, err := s.StopMaintain(context.Background(), &downloader.TargetRequest{Id: 42}); err == nil {
+		t.Error("error expected")
+	}
+}
+
+// maintain changes count of target tasks
+func maintain(t *testing.T, s *server, id uint64, count uint32) {
+	s.mutex.Lock()
+	urls := s.targets[id].urls
+	s.mutex.Unlock()
+	if _, err := s.Maintain(context.Background(), &downloader.MaintainRequest{Id: id, Urls: urls, Count: count}); err != nil {
+		t.Fatalf("cannot update target: %s", err)
+	}
+}
@@ -0,0 +1,72 @@
+package server
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+)
+
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// TLSSettings configures TLS connections of HTTPS streams
+type TLSSettings struct {
+	// Insecure disables verification of server certificates
+	Insecure bool
+
+	// CAFile is a PEM bundle of trusted certificate authorities. System pool is used if empty
+	CAFile string
+
+	// CertFile and KeyFile are PEM files of client certificate for mutual TLS
+	CertFile string
+	KeyFile  string
+
+	// ServerName overrides SNI and name of verified server certificate
+	ServerName string
+
+	// MinVersion is a minimal TLS version: 1.0, 1.1, 1.2 or 1.3. Default of crypto/tls is used if empty
+	MinVersion string
+}
+
+// config builds TLS configuration of tasks
+func (s TLSSettings) config() (*tls.Config, error) {
+	cfg := &tls.Config{InsecureSkipVerify: s.Insecure, ServerName: s.ServerName}
+
+	if s.MinVersion != "" {
+		version, ok := tlsVersions[s.MinVersion]
+		if !ok {
+			return nil, fmt.Errorf("unsupported TLS version: %s", s.MinVersion)
+		}
+		cfg.MinVersion = version
+	}
+
+	if s.CAFile != "" {
+		pem, err := os.ReadFile(s.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read CA bundle: %w", err)
+		}
+		cfg.RootCAs = x509.NewCertPool()
+		if !cfg.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in CA bundle %s", s.CAFile)
+		}
+	}
+
+	if (s.CertFile == "") != (s.KeyFile == "") {
+		return nil, errors.New("both client certificate and key must be set")
+	}
+	if s.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(s.CertFile, s.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("cannot load client certificate: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return cfg, nil
+}
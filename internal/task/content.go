@@ -0,0 +1,75 @@
+package task
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"sync"
+)
+
+// shortHashLen is a length of hash prefix in messages
+const shortHashLen = 12
+
+// ContentRegistry keeps hashes of content received by tasks, so tasks requesting the same URL detect that
+// origin serves different content. The oldest hashes are forgotten when capacity is exceeded
+type ContentRegistry struct {
+	mutex  sync.Mutex
+	hashes map[string]string
+	order  []string // ring of URLs in order of registration
+	next   int
+}
+
+// NewContentRegistry creates registry which keeps at most capacity hashes
+func NewContentRegistry(capacity int) *ContentRegistry {
+	return &ContentRegistry{hashes: make(map[string]string, capacity), order: make([]string, capacity)}
+}
+
+// check registers hash of content of URL or compares it with the registered one, which is returned
+func (r *ContentRegistry) check(url, sum string) (expected string, ok bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if expected, found := r.hashes[url]; found {
+		return expected, expected == sum
+	}
+
+	if old := r.order[r.next]; old != "" {
+		delete(r.hashes, old)
+	}
+	r.order[r.next] = url
+	r.next = (r.next + 1) % len(r.order)
+	r.hashes[url] = sum
+	return sum, true
+}
+
+// contentHash computes hash of the content received from URL. The content is limited by the first bytes if limit
+// is not zero
+type contentHash struct {
+	url   string
+	limit int
+	n     int
+	h     hash.Hash
+}
+
+func newContentHash(url string, limit int) *contentHash {
+	return &contentHash{url: url, limit: limit, h: sha256.New()}
+}
+
+// write hashes data and returns whether the limit is reached
+func (c *contentHash) write(data []byte) bool {
+	if c.limit != 0 && c.n+len(data) > c.limit {
+		data = data[:c.limit-c.n]
+	}
+	c.h.Write(data)
+	c.n += len(data)
+	return c.limit != 0 && c.n >= c.limit
+}
+
+func (c *contentHash) sum() string {
+	return hex.EncodeToString(c.h.Sum(nil))
+}
+
+func mismatchError(url, got, expected string) error {
+	return fmt.Errorf("content hash mismatch of %s: %s, expected %s", url, got[:shortHashLen], expected[:shortHashLen])
+}
@@ -0,0 +1,76 @@
+package task
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestContentVerification(t *testing.T) {
+	payload := bytes.Repeat([]byte{0x47}, 188)
+	sum := sha256.Sum256(payload)
+
+	tests := []struct {
+		name        string
+		verifyBytes int
+		expected    string // hash registered by other task
+		verified    uint32
+		mismatches  uint32
+	}{
+		{name: "first hash is reference", verifyBytes: len(payload), verified: 1},
+		{name: "same content", verifyBytes: len(payload), expected: hex.EncodeToString(sum[:]), verified: 1},
+		{name: "mismatch does not interrupt session", verifyBytes: len(payload), expected: strings.Repeat("0", 64), mismatches: 1},
+		{name: "continuous stream is not hashed by default"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr := fmt.Sprintf("127.0.0.1:%d", freeUDPPort(t))
+			url := "udp://" + addr
+			task := NewTask(context.Background(), 1, url)
+			task.Timeout = 5 * time.Second
+			task.VerifyBytes = tt.verifyBytes
+			task.Contents = NewContentRegistry(10)
+			if tt.expected != "" {
+				task.Contents.check(url, tt.expected)
+			}
+
+			var wg sync.WaitGroup
+			task.Run(&wg)
+			defer wg.Wait()
+			defer task.Stop()
+
+			conn, err := net.Dial("udp", addr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer conn.Close()
+
+			waitFor(t, task, func(info Info) bool {
+				_, _ = conn.Write(payload)
+				return info.Packets != 0
+			})
+			base := task.Info().Packets
+			for i := 0; i < 3; i++ {
+				if _, err = conn.Write(payload); err != nil {
+					t.Fatal(err)
+				}
+			}
+			info := waitFor(t, task, func(info Info) bool { return info.Packets >= base+3 })
+
+			if info.Verified != tt.verified || info.Mismatches != tt.mismatches {
+				t.Errorf("got verified %d, mismatches %d, want %d, %d", info.Verified, info.Mismatches, tt.verified, tt.mismatches)
+			}
+			if info.Status != StatusActive || len(info.Failures) != 0 {
+				t.Errorf("session is interrupted: status %s, failures %v", info.Status, info.Failures)
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package task
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/url"
+	"time"
+
+	"github.com/racoon-devel/downloader/internal/dash"
+)
+
+// defaultSegmentDuration is a polling interval of live presentation if segment duration is unknown
+const defaultSegmentDuration = 2 * time.Second
+
+// dashPlayback is a state of DASH presentation playing
+type dashPlayback struct {
+	mpd     *dash.MPD
+	period  int
+	tracks  []dash.Track
+	inits   []string
+	cursors []time.Duration // presentation time of the next segment to fetch for each track
+	started []bool
+}
+
+// dashStreamer plays MPEG-DASH presentation: chooses representation of each adaptation set and fetches segments
+// on the timeline, refreshing manifest of live presentation
+type dashStreamer struct{}
+
+func (dashStreamer) Receive(ctx context.Context, s *Session) error {
+	log.Printf("[%s] Loading manifest...", s.URL())
+
+	mpd, f := s.loadManifest(ctx)
+	if f != nil {
+		return f
+	}
+
+	playback := &dashPlayback{}
+	if f = s.switchPeriod(playback, mpd, mpd.CurrentPeriod(time.Now())); f != nil {
+		return f
+	}
+
+	s.Connected()
+
+	lastProgress := time.Now()
+	lastLoad := time.Now()
+
+	for {
+		fresh := 0
+		interval := time.Duration(0)
+
+		for i := range playback.tracks {
+			track := &playback.tracks[i]
+
+			if init := track.InitURL(); init != "" && init != playback.inits[i] {
+				if f = s.fetch(ctx, init); f != nil {
+					return f
+				}
+				playback.inits[i] = init
+			}
+
+			segments, err := track.Segments(playback.mpd, time.Now())
+			if err != nil {
+				return &Failure{Reason: FailureManifest, Err: err, Retry: true}
+			}
+			if !playback.started[i] && len(segments) != 0 {
+				playback.cursors[i] = dashStartTime(segments, playback.mpd.IsDynamic())
+				playback.started[i] = true
+			}
+
+			for _, segment := range segments {
+				if segment.Start < playback.cursors[i] {
+					continue
+				}
+
+				fetchStart := time.Now()
+				if f = s.fetch(ctx, segment.URL); f != nil {
+					return f
+				}
+				latency := time.Since(fetchStart)
+				s.SegmentFetched(latency)
+
+				if segment.Duration != 0 && latency > segment.Duration {
+					log.Printf("[%s] Stall: segment %s fetched in %s, longer than its duration %s", s.URL(), segment.URL,
+						latency.Round(time.Millisecond), segment.Duration)
+					s.Stalled()
+				}
+
+				playback.cursors[i] = segment.Start + segment.Duration
+				fresh++
+			}
+
+			if n := len(segments); n != 0 && (interval == 0 || segments[n-1].Duration < interval) {
+				interval = segments[n-1].Duration
+			}
+		}
+
+		if !playback.mpd.IsDynamic() {
+			if playback.period+1 < len(playback.mpd.Periods) {
+				if f = s.switchPeriod(playback, playback.mpd, playback.period+1); f != nil {
+					return f
+				}
+				continue
+			}
+			return &Failure{Reason: FailureEOF, Err: errors.New("presentation ended"), Retry: true}
+		}
+
+		if interval == 0 {
+			interval = defaultSegmentDuration
+		}
+		if fresh != 0 {
+			lastProgress = time.Now()
+		} else if stall := time.Duration(playlistStallFactor * float64(interval)); time.Since(lastProgress) > stall {
+			log.Printf("[%s] Stall: no new segments during %s", s.URL(), time.Since(lastProgress).Round(time.Millisecond))
+			s.Stalled()
+			lastProgress = time.Now()
+		}
+
+		if fresh == 0 {
+			interval /= 2
+		}
+		select {
+		case <-ctx.Done():
+			return &Failure{Reason: FailureRead, Err: ctx.Err()}
+		case <-time.After(interval):
+		}
+
+		mpd = playback.mpd
+		if mpd.MinimumUpdatePeriod != 0 && time.Since(lastLoad) >= mpd.MinimumUpdatePeriod {
+			if mpd, f = s.loadManifest(ctx); f != nil {
+				return f
+			}
+			lastLoad = time.Now()
+		}
+
+		period := mpd.CurrentPeriod(time.Now())
+		if period != playback.period || mpd != playback.mpd {
+			if f = s.switchPeriod(playback, mpd, period); f != nil {
+				return f
+			}
+		}
+	}
+}
+
+// switchPeriod chooses tracks of the period. The playback position is kept if the period is not changed
+func (s *Session) switchPeriod(playback *dashPlayback, mpd *dash.MPD, period int) *Failure {
+	mpdURL, _ := url.Parse(s.URL())
+	tracks, err := mpd.Tracks(period, mpdURL, s.t.Variant.Choose)
+	if err != nil {
+		return &Failure{Reason: FailureManifest, Err: err, Retry: true}
+	}
+
+	samePeriod := playback.mpd != nil && playback.period == period && len(playback.tracks) == len(tracks)
+	playback.mpd = mpd
+	playback.period = period
+	playback.tracks = tracks
+	if samePeriod {
+		return nil
+	}
+
+	playback.inits = make([]string, len(tracks))
+	playback.cursors = make([]time.Duration, len(tracks))
+	playback.started = make([]bool, len(tracks))
+	var bandwidth float64
+	for _, track := range tracks {
+		log.Printf("[%s] Representation selected: %s (bandwidth: %d)", s.URL(), track.Representation.ID,
+			track.Representation.Bandwidth)
+		bandwidth += float64(track.Representation.Bandwidth)
+	}
+	s.NominalBitrate(bandwidth)
+	return nil
+}
+
+// dashStartTime returns presentation time to start playback from
+func dashStartTime(segments []dash.Segment, dynamic bool) time.Duration {
+	if !dynamic || len(segments) == 0 {
+		return 0
+	}
+	if len(segments) > liveStartSegments {
+		return segments[len(segments)-liveStartSegments].Start
+	}
+	return segments[0].Start
+}
+
+func (s *Session) loadManifest(parent context.Context) (*dash.MPD, *Failure) {
+	ctx, cancel := context.WithCancel(parent)
+	defer cancel()
+
+	resp, f := s.get(ctx, s.URL())
+	if f != nil {
+		return nil, f
+	}
+	defer resp.Body.Close()
+
+	content := bytes.Buffer{}
+	if f = s.read(ctx, cancel, io.TeeReader(resp.Body, &content), false); f != nil {
+		return nil, f
+	}
+
+	mpd, err := dash.Parse(content.Bytes())
+	if err != nil {
+		return nil, &Failure{Reason: FailureManifest, Err: fmt.Errorf("parse manifest failed: %w", err), Retry: true}
+	}
+	return mpd, nil
+}
@@ -0,0 +1,70 @@
+package task
+
+import "time"
+
+// EventType is a kind of task lifecycle event
+type EventType int
+
+const (
+	EventAdded EventType = iota
+	EventConnecting
+	EventConnected
+	EventFirstByte
+	EventStall
+	EventTimeout
+	EventError
+	EventReconnecting
+	EventStopped
+	EventFailed
+)
+
+func (e EventType) String() string {
+	switch e {
+	case EventAdded:
+		return "added"
+	case EventConnecting:
+		return "connecting"
+	case EventConnected:
+		return "connected"
+	case EventFirstByte:
+		return "first_byte"
+	case EventStall:
+		return "stall"
+	case EventTimeout:
+		return "timeout"
+	case EventError:
+		return "error"
+	case EventReconnecting:
+		return "reconnecting"
+	case EventStopped:
+		return "stopped"
+	case EventFailed:
+		return "failed"
+	default:
+		return "unknown"
+	}
+}
+
+// Event is a notification about task lifecycle
+type Event struct {
+	Type   EventType
+	TaskID uint64
+	Time   time.Time
+
+	// Reason is a failure reason of timeout and error events
+	Reason string
+
+	// Message is a human-readable details
+	Message string
+}
+
+// NewEvent creates event of the task occurred now
+func NewEvent(kind EventType, taskID uint64, reason, message string) Event {
+	return Event{Type: kind, TaskID: taskID, Time: time.Now(), Reason: reason, Message: message}
+}
+
+func (t *Task) emit(kind EventType, reason, message string) {
+	if t.OnEvent != nil {
+		t.OnEvent(NewEvent(kind, t.id, reason, message))
+	}
+}
@@ -0,0 +1,85 @@
+package task
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+	"strings"
+)
+
+// Failure reasons of stream session
+const (
+	FailureRequest     = "request"
+	FailureDNS         = "dns"
+	FailureConnect     = "connect"
+	FailureTLS         = "tls"
+	FailureClientError = "http_4xx"
+	FailureServerError = "http_5xx"
+	FailureStatus      = "status"
+	FailureTimeout     = "timeout"
+	FailureEOF         = "eof"
+	FailureRead        = "read"
+	FailureManifest    = "manifest"
+)
+
+// Failure describes why stream session has been interrupted
+type Failure struct {
+	Reason string
+	Err    error
+
+	// Retry allows reconnecting after the failure
+	Retry bool
+}
+
+func (f *Failure) Error() string {
+	return f.Reason + ": " + f.Err.Error()
+}
+
+func (f *Failure) Unwrap() error {
+	return f.Err
+}
+
+// connectFailure classifies error of establishing connection
+func connectFailure(err error) *Failure {
+	return &Failure{Reason: connectFailureReason(err), Err: err, Retry: true}
+}
+
+func connectFailureReason(err error) string {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return FailureDNS
+	}
+
+	var recordErr tls.RecordHeaderError
+	var authorityErr x509.UnknownAuthorityError
+	var invalidErr x509.CertificateInvalidError
+	var hostnameErr x509.HostnameError
+	if errors.As(err, &recordErr) || errors.As(err, &authorityErr) || errors.As(err, &invalidErr) ||
+		errors.As(err, &hostnameErr) || isTLSMessage(err.Error()) {
+		return FailureTLS
+	}
+
+	if isTimeout(err) {
+		return FailureTimeout
+	}
+	return FailureConnect
+}
+
+// isTLSMessage detects TLS errors which are not wrapped by net/http, including handshake timeout
+func isTLSMessage(msg string) bool {
+	return strings.Contains(msg, "tls: ") || strings.Contains(msg, "TLS handshake") ||
+		strings.Contains(msg, "server gave HTTP response to HTTPS client")
+}
+
+// statusFailureReason classifies unexpected HTTP status code
+func statusFailureReason(code int) string {
+	switch {
+	case code >= 400 && code < 500:
+		return FailureClientError
+	case code >= 500 && code < 600:
+		return FailureServerError
+	default:
+		return FailureStatus
+	}
+}
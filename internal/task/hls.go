@@ -0,0 +1,149 @@
+package task
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/url"
+	"time"
+
+	"github.com/racoon-devel/downloader/internal/hls"
+)
+
+// liveStartSegments is a count of segments from the end of live playlist to start playback with
+const liveStartSegments = 3
+
+// playlistStallFactor is a count of target durations without new segments which is considered as a stall
+const playlistStallFactor = 1.5
+
+// hlsStreamer plays HLS stream like a player: chooses variant, polls live media playlist and downloads new segments
+type hlsStreamer struct{}
+
+func (hlsStreamer) Receive(ctx context.Context, s *Session) error {
+	log.Printf("[%s] Loading playlist...", s.URL())
+
+	playlistURL := s.URL()
+	playlist, f := s.loadPlaylist(ctx, playlistURL)
+	if f != nil {
+		return f
+	}
+
+	if playlist.IsMaster() {
+		variant, _ := s.t.Variant.Select(playlist.Variants)
+		log.Printf("[%s] Variant selected: %s (bandwidth: %d)", s.URL(), variant.URL, variant.Bandwidth)
+		s.NominalBitrate(float64(variant.Bandwidth))
+
+		playlistURL = variant.URL
+		if playlist, f = s.loadPlaylist(ctx, playlistURL); f != nil {
+			return f
+		}
+		if playlist.IsMaster() {
+			return &Failure{Reason: FailureManifest, Err: errors.New("variant refers to master playlist")}
+		}
+	}
+
+	s.Connected()
+
+	lastSequence := startSequence(playlist)
+	initURL := ""
+	lastProgress := time.Now()
+
+	for {
+		if playlist.InitURL != "" && playlist.InitURL != initURL {
+			if f = s.fetch(ctx, playlist.InitURL); f != nil {
+				return f
+			}
+			initURL = playlist.InitURL
+		}
+
+		fresh := 0
+		for _, segment := range playlist.Segments {
+			if segment.Sequence <= lastSequence {
+				continue
+			}
+
+			fetchStart := time.Now()
+			if f = s.fetch(ctx, segment.URL); f != nil {
+				return f
+			}
+			latency := time.Since(fetchStart)
+			s.SegmentFetched(latency)
+
+			if segment.Duration != 0 && latency > segment.Duration {
+				log.Printf("[%s] Stall: segment %d fetched in %s, longer than its duration %s", s.URL(), segment.Sequence,
+					latency.Round(time.Millisecond), segment.Duration)
+				s.Stalled()
+			}
+
+			lastSequence = segment.Sequence
+			fresh++
+		}
+
+		if fresh != 0 {
+			lastProgress = time.Now()
+		} else if stall := time.Duration(playlistStallFactor * float64(playlist.TargetDuration)); time.Since(lastProgress) > stall {
+			log.Printf("[%s] Stall: no new segments during %s", s.URL(), time.Since(lastProgress).Round(time.Millisecond))
+			s.Stalled()
+			lastProgress = time.Now()
+		}
+
+		if playlist.EndList {
+			return &Failure{Reason: FailureEOF, Err: errors.New("playlist ended"), Retry: true}
+		}
+
+		// reload interval according to RFC 8216, 6.3.4
+		interval := playlist.TargetDuration
+		if fresh == 0 {
+			interval /= 2
+		}
+		select {
+		case <-ctx.Done():
+			return &Failure{Reason: FailureRead, Err: ctx.Err()}
+		case <-time.After(interval):
+		}
+
+		if playlist, f = s.loadPlaylist(ctx, playlistURL); f != nil {
+			return f
+		}
+
+		// media sequence is restarted by origin
+		if n := len(playlist.Segments); n != 0 && playlist.Segments[n-1].Sequence < lastSequence {
+			log.Printf("[%s] Media sequence restarted", s.URL())
+			lastSequence = startSequence(playlist)
+		}
+	}
+}
+
+// startSequence returns sequence number of the segment before the first one to download
+func startSequence(playlist *hls.Playlist) int64 {
+	if !playlist.EndList && len(playlist.Segments) > liveStartSegments {
+		return playlist.Segments[len(playlist.Segments)-liveStartSegments-1].Sequence
+	}
+	return playlist.MediaSequence - 1
+}
+
+func (s *Session) loadPlaylist(parent context.Context, playlistURL string) (*hls.Playlist, *Failure) {
+	ctx, cancel := context.WithCancel(parent)
+	defer cancel()
+
+	resp, f := s.get(ctx, playlistURL)
+	if f != nil {
+		return nil, f
+	}
+	defer resp.Body.Close()
+
+	content := bytes.Buffer{}
+	if f = s.read(ctx, cancel, io.TeeReader(resp.Body, &content), false); f != nil {
+		return nil, f
+	}
+
+	base, _ := url.Parse(playlistURL)
+	playlist, err := hls.Parse(&content, base)
+	if err != nil {
+		return nil, &Failure{Reason: FailureManifest, Err: fmt.Errorf("parse playlist failed: %w", err), Retry: true}
+	}
+	return playlist, nil
+}
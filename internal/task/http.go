@@ -0,0 +1,126 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+const readBufferSize = 65536
+
+// get performs request of the resource and checks response status. Caller must close body of returned response
+func (s *Session) get(ctx context.Context, url string) (*http.Response, *Failure) {
+	return s.getRange(ctx, url, 0)
+}
+
+// getRange performs request of the resource from the offset. Partial content is expected if offset is not zero
+func (s *Session) getRange(ctx context.Context, url string, offset uint64) (*http.Response, *Failure) {
+	req, err := s.newRequest(ctx, url)
+	if err != nil {
+		return nil, &Failure{Reason: FailureRequest, Err: err}
+	}
+	if offset != 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	requestTime := time.Now()
+	resp, err := s.HTTPClient().Do(req)
+	if err != nil {
+		return nil, connectFailure(err)
+	}
+	s.RequestCompleted(time.Since(requestTime))
+
+	if resp.StatusCode != http.StatusOK && !(offset != 0 && resp.StatusCode == http.StatusPartialContent) {
+		_ = resp.Body.Close()
+		return nil, &Failure{
+			Reason: statusFailureReason(resp.StatusCode),
+			Err:    fmt.Errorf("unexpected status code: %d", resp.StatusCode),
+			Retry:  s.t.Retry.retryStatus(resp.StatusCode),
+		}
+	}
+
+	return resp, nil
+}
+
+// read consumes body until EOF and accounts received bytes as media or service data. The context is cancelled
+// if no data arrives during timeout
+func (s *Session) read(ctx context.Context, cancel context.CancelFunc, body io.Reader, media bool) *Failure {
+	/*
+		Так как мы не можем для http.Response выставить таймаут для сокета, то
+		используем такой костыль для контролирования, что данные вообще приходят
+	*/
+	var expired int32
+	notifyCh := make(chan bool)
+	if s.Timeout() != 0 {
+		go func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-notifyCh:
+				case <-time.After(s.Timeout()):
+					atomic.StoreInt32(&expired, 1)
+					cancel()
+					return
+				}
+			}
+		}()
+	}
+
+	notify := func() {
+		if s.Timeout() != 0 {
+			select {
+			case notifyCh <- true:
+			case <-ctx.Done():
+			}
+		}
+	}
+
+	buffer := make([]byte, s.ReadSize())
+	for {
+		n, err := body.Read(buffer)
+		if media {
+			s.MediaReceived(buffer[:n])
+		} else {
+			s.Received(n)
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err == nil {
+			// waiting for rate limit is not counted as read timeout
+			notify()
+			err = s.Throttle(ctx, n)
+		}
+		if err != nil {
+			if atomic.LoadInt32(&expired) != 0 {
+				return &Failure{Reason: FailureTimeout, Err: errors.New("read timeout expired"), Retry: true}
+			}
+			return &Failure{Reason: FailureRead, Err: err, Retry: true}
+		}
+		notify()
+	}
+}
+
+// fetch downloads the whole media resource
+func (s *Session) fetch(parent context.Context, url string) *Failure {
+	ctx, cancel := context.WithCancel(parent)
+	defer cancel()
+
+	resp, f := s.get(ctx, url)
+	if f != nil {
+		return f
+	}
+	defer resp.Body.Close()
+
+	s.StartContent(url)
+	if f = s.read(ctx, cancel, resp.Body, true); f != nil {
+		return f
+	}
+	s.EndContent()
+	return nil
+}
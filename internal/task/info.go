@@ -0,0 +1,92 @@
+package task
+
+import (
+	"time"
+
+	"github.com/racoon-devel/downloader/internal/player"
+	"github.com/racoon-devel/downloader/internal/ts"
+)
+
+// Info is a snapshot of Task's state
+type Info struct {
+	ID            uint64
+	URL           string
+	Type          string
+	Status        Status
+	StartTime     time.Time
+	BytesReceived uint64
+	LastError     string
+
+	// FailureReason is a classified reason of the last failure, see Failure* constants
+	FailureReason string
+	Reconnects    uint32
+
+	// Bitrate is an average bits per second over the last few seconds
+	Bitrate float64
+
+	// TTFB is a time to first byte of the last connection
+	TTFB time.Duration
+
+	// DNSLookup is a duration of the last resolving of host name, zero if host has not been resolved
+	DNSLookup time.Duration
+
+	// Failures is a count of failed sessions by reason
+	Failures map[string]uint32
+
+	// Segments is a count of fetched HLS segments
+	Segments uint32
+
+	// SegmentLatency is an average duration of segment fetching
+	SegmentLatency time.Duration
+
+	// Stalls is a count of moments when the segments are not received in time
+	Stalls uint32
+
+	// Packets is a count of received datagrams
+	Packets uint64
+
+	// PacketRate is an average packets per second over the last few seconds
+	PacketRate float64
+
+	// PacketsLost is a count of RTP packets detected as lost by sequence numbers
+	PacketsLost uint64
+
+	// Malformed is a count of received datagrams which are not valid RTP packets
+	Malformed uint64
+
+	// Reordered is a count of RTP packets received after their successors
+	Reordered uint64
+
+	// Duplicates is a count of RTP packets received twice
+	Duplicates uint64
+
+	// Analysis is a result of MPEG-TS checking, it is nil if analyzer is disabled
+	Analysis *ts.Stats
+
+	// Playout is a state of simulated player, it is nil if simulation is disabled
+	Playout *player.Stats
+
+	// Recorded is a count of bytes written to the record
+	Recorded uint64
+
+	// Verified is a count of content hashes which match hashes received by other tasks
+	Verified uint32
+
+	// Mismatches is a count of content hashes which differ from hashes received by other tasks
+	Mismatches uint32
+
+	// Source is a local IP address of outgoing connections, empty if it is chosen by system
+	Source string
+}
+
+// Samples are latencies observed by task
+type Samples struct {
+	// Requests are latencies between sending request and receiving response headers
+	Requests []time.Duration
+
+	// Segments are durations of segments fetching
+	Segments []time.Duration
+
+	// DNS are durations of resolving host names
+	DNS []time.Duration
+}
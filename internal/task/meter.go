@@ -0,0 +1,47 @@
+package task
+
+import "time"
+
+// bitrateWindow is a count of complete seconds to average data rate over
+const bitrateWindow = 5
+
+// meter measures data rate over sliding window of one second buckets
+type meter struct {
+	buckets [bitrateWindow + 1]uint64
+	seconds [bitrateWindow + 1]int64
+	first   int64
+}
+
+func (m *meter) add(now time.Time, n int) {
+	sec := now.Unix()
+	if m.first == 0 {
+		m.first = sec
+	}
+	i := sec % int64(len(m.buckets))
+	if m.seconds[i] != sec {
+		m.seconds[i] = sec
+		m.buckets[i] = 0
+	}
+	m.buckets[i] += uint64(n)
+}
+
+// rate returns average bytes per second within the window. The current incomplete second is not considered
+func (m *meter) rate(now time.Time) float64 {
+	sec := now.Unix()
+	var total uint64
+	for i := range m.buckets {
+		if age := sec - m.seconds[i]; age >= 1 && age <= bitrateWindow {
+			total += m.buckets[i]
+		}
+	}
+
+	// window is not filled yet just after start
+	window := sec - m.first
+	if window > bitrateWindow {
+		window = bitrateWindow
+	}
+	if window <= 0 {
+		return 0
+	}
+	return float64(total) / float64(window)
+}
@@ -0,0 +1,34 @@
+package task
+
+import (
+	"context"
+	"io"
+	"log"
+)
+
+// progressiveStreamer receives continuous stream by single HTTP request
+type progressiveStreamer struct{}
+
+func (progressiveStreamer) Receive(parent context.Context, s *Session) error {
+	ctx, cancel := context.WithCancel(parent)
+	defer cancel()
+
+	log.Printf("[%s] Connecting...", s.URL())
+
+	resp, f := s.getRange(ctx, s.URL(), s.t.Request.RangeStart)
+	if f != nil {
+		return f
+	}
+	defer resp.Body.Close()
+
+	s.Connected()
+
+	if f = s.read(ctx, cancel, resp.Body, true); f != nil {
+		return f
+	}
+	// stream is shorter than verified part
+	s.EndContent()
+
+	// live stream is not expected to end
+	return &Failure{Reason: FailureEOF, Err: io.EOF, Retry: true}
+}
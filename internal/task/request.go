@@ -0,0 +1,116 @@
+package task
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// RequestOptions customize HTTP requests of the task. Header and query values may contain templates,
+// see ValidateTemplate
+type RequestOptions struct {
+	// Method is a method of all requests, GET if empty
+	Method string
+
+	// Header is added to each request
+	Header http.Header
+
+	// Query parameters are set in URL of each request
+	Query url.Values
+
+	// RangeStart is an offset of continuous HTTP stream requested by Range header, whole stream if zero
+	RangeStart uint64
+}
+
+var templateRe = regexp.MustCompile(`{{\s*(\w*)\s*}}`)
+
+// templateVars are names of variables which can be used in templates as {{name}}:
+//   - id: task ID;
+//   - seq: number of request of the task;
+//   - time: unix time of request;
+//   - rand: random 32-bit number;
+//   - uuid: random UUID.
+var templateVars = map[string]bool{"id": true, "seq": true, "time": true, "rand": true, "uuid": true}
+
+// ValidateTemplate checks that template refers to known variables only
+func ValidateTemplate(template string) error {
+	for _, match := range templateRe.FindAllStringSubmatch(template, -1) {
+		if !templateVars[match[1]] {
+			return fmt.Errorf("unknown template variable: %s", match[0])
+		}
+	}
+	return nil
+}
+
+// expand substitutes variables of template for the request
+func (t *Task) expand(template string, seq uint64) string {
+	return templateRe.ReplaceAllStringFunc(template, func(match string) string {
+		switch templateRe.FindStringSubmatch(match)[1] {
+		case "id":
+			return strconv.FormatUint(t.id, 10)
+		case "seq":
+			return strconv.FormatUint(seq, 10)
+		case "time":
+			return strconv.FormatInt(time.Now().Unix(), 10)
+		case "rand":
+			var b [4]byte
+			_, _ = rand.Read(b[:])
+			return strconv.FormatUint(uint64(binary.BigEndian.Uint32(b[:])), 10)
+		case "uuid":
+			var b [16]byte
+			_, _ = rand.Read(b[:])
+			b[6] = b[6]&0x0f | 0x40
+			b[8] = b[8]&0x3f | 0x80
+			return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+		}
+		return match
+	})
+}
+
+// newRequest creates request of the task with customized method, headers and query parameters
+func (s *Session) newRequest(ctx context.Context, rawURL string) (*http.Request, error) {
+	opts := &s.t.Request
+	seq := s.t.nextRequest()
+
+	if len(opts.Query) != 0 {
+		u, err := url.Parse(rawURL)
+		if err != nil {
+			return nil, err
+		}
+		query := u.Query()
+		for name, values := range opts.Query {
+			query.Del(name)
+			for _, value := range values {
+				query.Add(name, s.t.expand(value, seq))
+			}
+		}
+		u.RawQuery = query.Encode()
+		rawURL = u.String()
+	}
+
+	method := opts.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+	req, err := http.NewRequestWithContext(s.traced(ctx), method, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for name, values := range opts.Header {
+		for _, value := range values {
+			req.Header.Add(name, s.t.expand(value, seq))
+		}
+	}
+	// Host header is not sent from Header map
+	if host := req.Header.Get("Host"); host != "" {
+		req.Host = host
+	}
+	return req, nil
+}
@@ -0,0 +1,151 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http/httptrace"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const defaultDNSPort = "53"
+
+// HostOverrides map "host:port" to addresses which are connected instead of resolving the host. Port "*" matches
+// any port
+type HostOverrides map[string][]string
+
+// Add parses override in curl --resolve format: host:port:addr[,addr]..., IPv6 addresses may be in brackets
+func (o HostOverrides) Add(entry string) error {
+	parts := strings.SplitN(entry, ":", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[2] == "" {
+		return fmt.Errorf("host override must be in form host:port:addr[,addr]: %s", entry)
+	}
+	host, port := strings.ToLower(parts[0]), parts[1]
+	if port != "*" {
+		if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+			return fmt.Errorf("invalid port of host override: %s", entry)
+		}
+	}
+
+	var addrs []string
+	for _, addr := range strings.Split(parts[2], ",") {
+		addr = strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(addr), "["), "]")
+		if net.ParseIP(addr) == nil {
+			return fmt.Errorf("invalid address of host override: %s", entry)
+		}
+		addrs = append(addrs, addr)
+	}
+	o[net.JoinHostPort(host, port)] = addrs
+	return nil
+}
+
+// lookup returns addresses overriding the host, exact port takes precedence over "*"
+func (o HostOverrides) lookup(host, port string) []string {
+	host = strings.ToLower(host)
+	if addrs, ok := o[net.JoinHostPort(host, port)]; ok {
+		return addrs
+	}
+	return o[net.JoinHostPort(host, "*")]
+}
+
+// Merge returns overrides of o updated by other
+func (o HostOverrides) Merge(other HostOverrides) HostOverrides {
+	res := make(HostOverrides, len(o)+len(other))
+	for key, addrs := range o {
+		res[key] = addrs
+	}
+	for key, addrs := range other {
+		res[key] = addrs
+	}
+	return res
+}
+
+// validateDNSServer checks address of DNS server: host or host:port
+func validateDNSServer(server string) error {
+	if server == "" {
+		return nil
+	}
+	host := server
+	if h, _, err := net.SplitHostPort(server); err == nil {
+		host = h
+	}
+	if net.ParseIP(host) == nil {
+		return fmt.Errorf("DNS server must be IP address: %s", server)
+	}
+	return nil
+}
+
+// dialer connects to overridden addresses of hosts and resolves other hosts by configured DNS server
+type dialer struct {
+	net.Dialer
+	hosts HostOverrides
+}
+
+func (s TransportSettings) dialer() *dialer {
+	d := &dialer{Dialer: net.Dialer{Timeout: s.DialTimeout}, hosts: s.Hosts}
+	if s.Source != nil {
+		d.LocalAddr = sourceAddr("tcp", s.Source)
+	}
+	if s.DNSServer != "" {
+		server := s.DNSServer
+		if _, _, err := net.SplitHostPort(server); err != nil {
+			server = net.JoinHostPort(server, defaultDNSPort)
+		}
+		source := s.Source
+		d.Resolver = &net.Resolver{
+			PreferGo: true,
+			Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+				// DNS queries are sent from the source address too
+				dnsDialer := net.Dialer{Timeout: s.DialTimeout}
+				if source != nil {
+					dnsDialer.LocalAddr = sourceAddr(network, source)
+				}
+				return dnsDialer.DialContext(ctx, network, server)
+			},
+		}
+	}
+	return d
+}
+
+// sourceAddr makes local address of the network bound to the source IP
+func sourceAddr(network string, ip net.IP) net.Addr {
+	if strings.HasPrefix(network, "udp") {
+		return &net.UDPAddr{IP: ip}
+	}
+	return &net.TCPAddr{IP: ip}
+}
+
+// DialContext connects to the address. Overridden addresses of the host are tried in order
+func (d *dialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return d.Dialer.DialContext(ctx, network, address)
+	}
+	addrs := d.hosts.lookup(host, port)
+	if len(addrs) == 0 {
+		return d.Dialer.DialContext(ctx, network, address)
+	}
+
+	for _, addr := range addrs {
+		var conn net.Conn
+		if conn, err = d.Dialer.DialContext(ctx, network, net.JoinHostPort(addr, port)); err == nil {
+			return conn, nil
+		}
+	}
+	return nil, err
+}
+
+// traced returns context which reports DNS lookups of connections to the task
+func (s *Session) traced(ctx context.Context) context.Context {
+	var start time.Time
+	return httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) {
+			start = time.Now()
+		},
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			s.t.dnsLookup(time.Since(start))
+		},
+	})
+}
@@ -0,0 +1,162 @@
+package task
+
+import (
+	"context"
+	"net"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestHostOverridesAdd(t *testing.T) {
+	tests := []struct {
+		entry     string
+		overrides HostOverrides
+		invalid   bool
+	}{
+		{entry: "example.com:443:10.0.0.1", overrides: HostOverrides{"example.com:443": {"10.0.0.1"}}},
+		{entry: "Example.COM:80:10.0.0.1,10.0.0.2", overrides: HostOverrides{"example.com:80": {"10.0.0.1", "10.0.0.2"}}},
+		{entry: "example.com:*:10.0.0.1", overrides: HostOverrides{"example.com:*": {"10.0.0.1"}}},
+		{entry: "example.com:443:[::1]", overrides: HostOverrides{"example.com:443": {"::1"}}},
+		{entry: "example.com:443:::1", overrides: HostOverrides{"example.com:443": {"::1"}}},
+		{entry: "example.com:443:[2001:db8::1], 10.0.0.1", overrides: HostOverrides{"example.com:443": {"2001:db8::1", "10.0.0.1"}}},
+		{entry: "example.com", invalid: true},
+		{entry: "example.com:443", invalid: true},
+		{entry: "example.com:443:", invalid: true},
+		{entry: ":443:10.0.0.1", invalid: true},
+		{entry: "example.com:https:10.0.0.1", invalid: true},
+		{entry: "example.com:65536:10.0.0.1", invalid: true},
+		{entry: "example.com:443:edge.example.com", invalid: true},
+		{entry: "example.com:443:10.0.0.1,", invalid: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.entry, func(t *testing.T) {
+			o := HostOverrides{}
+			err := o.Add(test.entry)
+			if test.invalid {
+				if err == nil {
+					t.Errorf("error expected, got %v", o)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(o, test.overrides) {
+				t.Errorf("got %v, want %v", o, test.overrides)
+			}
+		})
+	}
+}
+
+func TestHostOverridesLookup(t *testing.T) {
+	server := HostOverrides{}
+	for _, entry := range []string{"example.com:*:10.0.0.1", "example.com:443:10.0.0.2", "cdn.example.com:80:10.0.0.3"} {
+		if err := server.Add(entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+	own := HostOverrides{}
+	if err := own.Add("cdn.example.com:80:10.0.0.4"); err != nil {
+		t.Fatal(err)
+	}
+	merged := server.Merge(own)
+
+	tests := []struct {
+		host, port string
+		addrs      []string
+	}{
+		{host: "example.com", port: "443", addrs: []string{"10.0.0.2"}},
+		{host: "EXAMPLE.com", port: "8080", addrs: []string{"10.0.0.1"}},
+		{host: "cdn.example.com", port: "80", addrs: []string{"10.0.0.4"}},
+		{host: "cdn.example.com", port: "443"},
+		{host: "other.com", port: "80"},
+	}
+
+	for _, test := range tests {
+		t.Run(net.JoinHostPort(test.host, test.port), func(t *testing.T) {
+			if addrs := merged.lookup(test.host, test.port); !reflect.DeepEqual(addrs, test.addrs) {
+				t.Errorf("got %v, want %v", addrs, test.addrs)
+			}
+		})
+	}
+
+	// merge must not change the source overrides
+	if addrs := server.lookup("cdn.example.com", "80"); !reflect.DeepEqual(addrs, []string{"10.0.0.3"}) {
+		t.Errorf("server overrides are changed: %v", addrs)
+	}
+}
+
+func TestDialerOverride(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			_ = conn.Close()
+		}
+	}()
+	port := strconv.Itoa(l.Addr().(*net.TCPAddr).Port)
+
+	tests := []struct {
+		name    string
+		entry   string
+		failure bool
+	}{
+		{name: "single address", entry: "stream.invalid:" + port + ":127.0.0.1"},
+		{name: "first address is refused", entry: "stream.invalid:*:[::1],127.0.0.1"},
+		{name: "other port is not overridden", entry: "stream.invalid:1:127.0.0.1", failure: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			hosts := HostOverrides{}
+			if err := hosts.Add(test.entry); err != nil {
+				t.Fatal(err)
+			}
+			d := TransportSettings{Hosts: hosts}.dialer()
+			conn, err := d.DialContext(context.Background(), "tcp", net.JoinHostPort("stream.invalid", port))
+			if test.failure {
+				if err == nil {
+					_ = conn.Close()
+					t.Error("error expected")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			_ = conn.Close()
+		})
+	}
+}
+
+func TestValidateDNSServer(t *testing.T) {
+	tests := []struct {
+		server  string
+		invalid bool
+	}{
+		{server: ""},
+		{server: "8.8.8.8"},
+		{server: "127.0.0.1:5353"},
+		{server: "[::1]:53"},
+		{server: "::1"},
+		{server: "dns.google", invalid: true},
+		{server: "dns.google:53", invalid: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.server, func(t *testing.T) {
+			if err := validateDNSServer(test.server); (err != nil) != test.invalid {
+				t.Errorf("got %v, invalid %t", err, test.invalid)
+			}
+		})
+	}
+}
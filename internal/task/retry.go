@@ -0,0 +1,78 @@
+package task
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+const (
+	defaultInitialBackoff = 1 * time.Second
+	defaultMaxBackoff     = 30 * time.Second
+	defaultMultiplier     = 2.0
+)
+
+// RetryPolicy describes how Task reconnects after failure
+type RetryPolicy struct {
+	// MaxAttempts is a limit of reconnects in a row. Zero disables reconnecting, negative value means unlimited
+	MaxAttempts int
+
+	// InitialBackoff is a delay before the first reconnect
+	InitialBackoff time.Duration
+
+	// MaxBackoff limits growth of the delay
+	MaxBackoff time.Duration
+
+	// Multiplier is a factor of the delay growth for each next attempt
+	Multiplier float64
+
+	// Jitter is a fraction of the delay [0..1] which is randomly added or subtracted
+	Jitter float64
+
+	// RetryOnStatus is a list of HTTP status codes to reconnect on. Any unexpected status causes reconnect if empty
+	RetryOnStatus []int
+}
+
+// allowed checks whether attempt with the number (starting from 1) may be performed
+func (p RetryPolicy) allowed(attempt int) bool {
+	return p.MaxAttempts < 0 || attempt <= p.MaxAttempts
+}
+
+// retryStatus checks whether the status code is suitable for reconnect
+func (p RetryPolicy) retryStatus(code int) bool {
+	if len(p.RetryOnStatus) == 0 {
+		return true
+	}
+	for _, c := range p.RetryOnStatus {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff calculates delay before the attempt with the number (starting from 1)
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	initial := p.InitialBackoff
+	if initial <= 0 {
+		initial = defaultInitialBackoff
+	}
+	max := p.MaxBackoff
+	if max <= 0 {
+		max = defaultMaxBackoff
+	}
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = defaultMultiplier
+	}
+
+	delay := float64(initial) * math.Pow(multiplier, float64(attempt-1))
+	if delay > float64(max) {
+		delay = float64(max)
+	}
+	if p.Jitter > 0 {
+		delay += delay * math.Min(p.Jitter, 1) * (rand.Float64()*2 - 1)
+	}
+
+	return time.Duration(delay)
+}
@@ -0,0 +1,188 @@
+package task
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/racoon-devel/downloader/internal/throttle"
+)
+
+// Session is one attempt of stream receiving. It provides streamer with facilities shared by all protocols
+type Session struct {
+	t         *Task
+	startTime time.Time
+	connected bool
+	firstByte bool
+	packets   uint64
+	client    *http.Client
+	limiters  []*throttle.Limiter
+	content   *contentHash
+	sample    []byte
+}
+
+func newSession(t *Task) *Session {
+	t.resetAnalyzer()
+	return &Session{t: t, startTime: time.Now(), limiters: t.limiters()}
+}
+
+// URL returns URL of the stream
+func (s *Session) URL() string {
+	return s.t.url
+}
+
+// Timeout returns read timeout
+func (s *Session) Timeout() time.Duration {
+	return s.t.Timeout
+}
+
+// Connected reports that stream is established
+func (s *Session) Connected() {
+	log.Printf("[%s] Connected", s.t.url)
+	s.connected = true
+	if s.t.Contents != nil && s.t.VerifyBytes != 0 {
+		s.content = newContentHash(s.t.url, s.t.VerifyBytes)
+	}
+	s.t.setStatus(StatusActive)
+	s.t.emit(EventConnected, "", "")
+}
+
+// Received accounts received service data, e.g. manifests
+func (s *Session) Received(n int) {
+	s.t.received(n)
+}
+
+// MediaReceived accounts received media data
+func (s *Session) MediaReceived(data []byte) {
+	if len(data) != 0 {
+		s.firstByteReceived()
+	}
+	s.t.mediaReceived(data)
+	s.contentReceived(data)
+}
+
+// ReadSize returns size of buffer for reading the stream, so throttled reading is smooth
+func (s *Session) ReadSize() int {
+	size := readBufferSize
+	for _, l := range s.limiters {
+		if l.Burst() < size {
+			size = l.Burst()
+		}
+	}
+	return size
+}
+
+// Throttle blocks until n received bytes fit rate limits of the task
+func (s *Session) Throttle(ctx context.Context, n int) error {
+	for _, l := range s.limiters {
+		if err := l.Wait(ctx, n); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NominalBitrate reports bitrate of the stream announced by manifest
+func (s *Session) NominalBitrate(bps float64) {
+	s.t.setNominalBitrate(bps)
+}
+
+// PacketReceived accounts received datagram with media payload
+func (s *Session) PacketReceived(payload []byte) {
+	s.packets++
+	s.firstByteReceived()
+	s.t.packetReceived(payload)
+	s.contentReceived(payload)
+}
+
+// StartContent starts hashing of the whole resource which is received next, e.g. media segment
+func (s *Session) StartContent(url string) {
+	if s.t.Contents != nil {
+		s.content = newContentHash(url, 0)
+	}
+}
+
+// EndContent reports that the content is completely received, so its hash can be verified. Mismatch is counted,
+// but doesn't interrupt the session
+func (s *Session) EndContent() {
+	if s.content == nil {
+		return
+	}
+	if err := s.t.contentReceived(s.content); err != nil {
+		log.Printf("[%s] Content verification failed: %s", s.t.url, err)
+	}
+	s.content = nil
+}
+
+func (s *Session) contentReceived(data []byte) {
+	s.t.record(data)
+	if s.content != nil && s.content.write(data) {
+		s.EndContent()
+	}
+	if capture := s.t.Capture; capture != 0 && s.t.Storage != nil && len(s.sample) < capture {
+		if len(data) > capture-len(s.sample) {
+			data = data[:capture-len(s.sample)]
+		}
+		s.sample = append(s.sample, data...)
+		if len(s.sample) == capture {
+			s.t.saveSample(s.sample)
+		}
+	}
+}
+
+// PacketMalformed accounts datagram which is not a valid packet of the protocol
+func (s *Session) PacketMalformed() {
+	s.t.packetMalformed()
+}
+
+// PacketsLost accounts packets which are detected as lost
+func (s *Session) PacketsLost(n int) {
+	s.t.packetsLost(n)
+}
+
+// PacketOutOfOrder accounts packet which is received after its successors or received twice
+func (s *Session) PacketOutOfOrder(duplicate bool) {
+	s.t.packetOutOfOrder(duplicate)
+}
+
+func (s *Session) firstByteReceived() {
+	if !s.firstByte {
+		s.firstByte = true
+		ttfb := time.Since(s.startTime)
+		s.t.setTTFB(ttfb)
+		s.t.emit(EventFirstByte, "", "TTFB "+ttfb.Round(time.Millisecond).String())
+	}
+}
+
+// RequestCompleted accounts latency between sending request and receiving response
+func (s *Session) RequestCompleted(latency time.Duration) {
+	s.t.observeLatency(latency)
+}
+
+// SegmentFetched accounts fetched media segment
+func (s *Session) SegmentFetched(latency time.Duration) {
+	s.t.segmentFetched(latency)
+}
+
+// Stalled reports that media is not received in time
+func (s *Session) Stalled() {
+	s.t.stalled()
+	s.t.emit(EventStall, "", "")
+}
+
+// HTTPClient returns HTTP client which is shared by requests of the session. Connections are pooled by
+// transport of the task
+func (s *Session) HTTPClient() *http.Client {
+	if s.client == nil {
+		s.client = &http.Client{Transport: s.t.httpTransport()}
+	}
+	return s.client
+}
+
+func (s *Session) close() {
+	// session is interrupted before the sample is filled
+	if len(s.sample) != 0 && len(s.sample) < s.t.Capture {
+		s.t.saveSample(s.sample)
+	}
+}
@@ -7,4 +7,26 @@ const (
 	StatusConnecting Status = iota
 	StatusActive
 	StatusError
+	StatusStopped
+	StatusReconnecting
+	StatusQueued
 )
+
+func (s Status) String() string {
+	switch s {
+	case StatusConnecting:
+		return "connecting"
+	case StatusActive:
+		return "active"
+	case StatusError:
+		return "failed"
+	case StatusStopped:
+		return "stopped"
+	case StatusReconnecting:
+		return "reconnecting"
+	case StatusQueued:
+		return "queued"
+	default:
+		return "unknown"
+	}
+}
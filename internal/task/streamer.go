@@ -0,0 +1,94 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+)
+
+// Types of builtin streamers
+const (
+	TypeHTTP = "http"
+	TypeHLS  = "hls"
+	TypeDASH = "dash"
+	TypeTCP  = "tcp"
+	TypeUDP  = "udp"
+	TypeRTP  = "rtp"
+)
+
+// Streamer receives stream of a particular protocol
+type Streamer interface {
+	// Receive performs one session of stream receiving until failure or cancellation of the context.
+	// Progress must be reported through the session. Errors other than *Failure are considered as read failures
+	Receive(ctx context.Context, s *Session) error
+}
+
+// StreamerFactory creates streamer for a task
+type StreamerFactory func() Streamer
+
+var (
+	streamersMutex sync.RWMutex
+	streamers      = make(map[string]StreamerFactory)
+)
+
+func init() {
+	Register(TypeHTTP, func() Streamer { return progressiveStreamer{} })
+	Register(TypeHLS, func() Streamer { return hlsStreamer{} })
+	Register(TypeDASH, func() Streamer { return dashStreamer{} })
+	Register(TypeTCP, func() Streamer { return tcpStreamer{} })
+	Register(TypeUDP, func() Streamer { return udpStreamer{} })
+	Register(TypeRTP, func() Streamer { return udpStreamer{rtp: true} })
+}
+
+// Register makes streamer available by the type name. Type name is also used as URL scheme for detection
+func Register(kind string, factory StreamerFactory) {
+	streamersMutex.Lock()
+	defer streamersMutex.Unlock()
+	streamers[kind] = factory
+}
+
+// IsRegistered checks whether streamer of the type is available
+func IsRegistered(kind string) bool {
+	streamersMutex.RLock()
+	defer streamersMutex.RUnlock()
+	_, ok := streamers[kind]
+	return ok
+}
+
+// DetectType determines stream type by URL scheme. Type of HTTP(S) stream is determined by file extension
+func DetectType(rawURL string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+
+	scheme := strings.ToLower(u.Scheme)
+	if scheme == "http" || scheme == "https" {
+		switch strings.ToLower(path.Ext(u.Path)) {
+		case ".m3u8":
+			return TypeHLS, nil
+		case ".mpd":
+			return TypeDASH, nil
+		default:
+			return TypeHTTP, nil
+		}
+	}
+
+	if !IsRegistered(scheme) {
+		return "", fmt.Errorf("unsupported URL scheme: %s", u.Scheme)
+	}
+	return scheme, nil
+}
+
+func newStreamer(kind string) (Streamer, error) {
+	streamersMutex.RLock()
+	defer streamersMutex.RUnlock()
+	factory, ok := streamers[kind]
+	if !ok {
+		return nil, fmt.Errorf("unsupported stream type: %s", kind)
+	}
+	return factory(), nil
+}
@@ -3,116 +3,645 @@ package task
 import (
 	"context"
 	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
 	"log"
-	"net/http"
 	"sync"
 	"time"
-)
 
-const readBufferSize = 65536
+	"github.com/racoon-devel/downloader/internal/hls"
+	"github.com/racoon-devel/downloader/internal/player"
+	"github.com/racoon-devel/downloader/internal/record"
+	"github.com/racoon-devel/downloader/internal/throttle"
+	"github.com/racoon-devel/downloader/internal/ts"
+)
 
-// Task implements HTTP stream download session
+// Task implements stream download session
 type Task struct {
+	// Type is a name of registered streamer. It is detected by URL if empty
+	Type string
+
 	// Timeout is a socket read timeout
 	Timeout time.Duration
 
+	// Retry is a policy of reconnecting after failures
+	Retry RetryPolicy
+
+	// Analyze enables MPEG-TS sanity checking of received media
+	Analyze bool
+
+	// Playout enables simulation of player buffer which is drained in real time
+	Playout bool
+
+	// PlayoutBitrate is a nominal bitrate of the stream for playout simulation (bits per second). It is taken
+	// from HLS variant or DASH representations if zero
+	PlayoutBitrate float64
+
+	// Storage keeps records and samples of received media
+	Storage *record.Storage
+
+	// Record enables writing received media to Storage
+	Record bool
+
+	// Capture is a count of the first bytes of each session which are saved to Storage as a sample, disabled if zero
+	Capture int
+
+	// Contents is a registry of content hashes shared by tasks, verification is disabled if nil
+	Contents *ContentRegistry
+
+	// VerifyBytes is a count of the first bytes of continuous stream which are hashed for verification, e.g. of
+	// VOD file. Continuous stream is not hashed if zero, HLS and DASH segments are hashed entirely
+	VerifyBytes int
+
+	// RateLimit limits rate of reading the stream (bits per second), unlimited if zero
+	RateLimit float64
+
+	// SharedLimiter limits rate of reading together with other tasks, e.g. by server-wide limit. Optional
+	SharedLimiter *throttle.Limiter
+
+	// OnEvent is called on lifecycle events of the task. It must not block
+	OnEvent func(Event)
+
+	// TLS configures HTTPS connections, default configuration of crypto/tls is used if nil
+	TLS *tls.Config
+
+	// Transport is an HTTP transport shared with other tasks. If nil, the task creates own transport
+	// by TransportSettings, so it acts as a distinct client
+	Transport *Transport
+
+	// TransportSettings configure own HTTP transport and dialing of TCP streams
+	TransportSettings TransportSettings
+
+	// Request customizes HTTP requests
+	Request RequestOptions
+
+	// Variant is a policy of choosing variant stream of HLS master playlist or DASH representation
+	Variant hls.VariantPolicy
+
+	id     uint64
 	url    string
-	ctx    context.Context
-	cancel context.CancelFunc
-	status Status
+	parent context.Context
+
+	mutex      sync.Mutex
+	ctx        context.Context
+	cancel     context.CancelFunc
+	done       chan struct{}
+	stopped    bool
+	restarting bool // stopped by BeginRestart, cleared by Stop
+	queued     bool
+	status     Status
+	startTime  time.Time
+	bytes      uint64
+	meter      meter
+	ttfb       time.Duration
+	dnsTime    time.Duration
+	lastError  string
+	lastReason string
+	reconnects uint32
+	failures   map[string]uint32
+	samples    Samples
+	segments   uint32
+	segTime    time.Duration
+	stalls     uint32
+	packets    uint64
+	pktMeter   meter
+	lost       uint64
+	malformed  uint64
+	reordered  uint64
+	duplicates uint64
+	analyzer   *ts.Analyzer
+	limiter    *throttle.Limiter
+	player     *player.Buffer
+	recorder   *record.Recorder
+	transport  *Transport // own transport of the task
+	recordErr  bool       // the last writing to record is failed
+	verified   uint32
+	mismatches uint32
+	requests   uint64
 }
 
 // NewTask creates initialized task
-func NewTask(ctx context.Context, url string) *Task {
-	t := &Task{url: url}
+func NewTask(ctx context.Context, id uint64, url string) *Task {
+	t := &Task{id: id, url: url, parent: ctx, failures: make(map[string]uint32)}
 	t.ctx, t.cancel = context.WithCancel(ctx)
 	return t
 }
 
+// ID returns server-assigned task identifier
+func (t *Task) ID() uint64 {
+	return t.id
+}
+
 // Run starts async stream receiving
 func (t *Task) Run(wg *sync.WaitGroup) {
+	t.mutex.Lock()
+	t.startTime = time.Now()
+	t.done = make(chan struct{})
+	t.queued = false
+	if t.Playout {
+		t.player = player.NewBuffer(t.startTime, t.PlayoutBitrate, player.DefaultStartBuffer)
+	}
+	if !t.stopped {
+		t.status = StatusConnecting
+	}
+	ctx, done := t.ctx, t.done
+	t.mutex.Unlock()
+
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		t.process()
+		defer close(done)
+		t.process(ctx)
 	}()
 }
 
+// BeginRestart stops the task for restart, which is completed by FinishRestart. It returns false if restart
+// of the task is already in progress, so concurrent restarts are merged into one
+func (t *Task) BeginRestart() bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if t.restarting {
+		return false
+	}
+	t.restarting = true
+	t.stopped = true
+	t.cancel()
+	return true
+}
+
+// FinishRestart waits for completion of the task stopped by BeginRestart and starts it again. Nothing is done if
+// the task has been stopped after BeginRestart. Task which has not been run yet is only reset, so it will be
+// started as usual
+func (t *Task) FinishRestart(wg *sync.WaitGroup) {
+	for {
+		started := t.Wait()
+		t.mutex.Lock()
+		// the task may be run by admission while waiting
+		if started == (t.done != nil) {
+			break
+		}
+		t.mutex.Unlock()
+	}
+
+	if !t.restarting {
+		t.mutex.Unlock()
+		return
+	}
+	t.restarting = false
+	t.ctx, t.cancel = context.WithCancel(t.parent)
+	t.stopped = false
+	t.status = StatusConnecting
+	if t.queued {
+		t.status = StatusQueued
+	}
+	t.lastError = ""
+	t.lastReason = ""
+	started := t.done != nil
+	t.mutex.Unlock()
+
+	if started {
+		t.Run(wg)
+	}
+}
+
+// Wait blocks until the running task is completed. It returns false if the task has not been run yet
+func (t *Task) Wait() bool {
+	t.mutex.Lock()
+	done := t.done
+	t.mutex.Unlock()
+
+	if done == nil {
+		return false
+	}
+	<-done
+	return true
+}
+
+// Enqueue marks task which has not been run yet as waiting for admission
+func (t *Task) Enqueue() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if t.done == nil && !t.stopped {
+		t.queued = true
+		t.status = StatusQueued
+	}
+}
+
 // Status gets task state
-func (t Task) Status() Status {
+func (t *Task) Status() Status {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
 	return t.status
 }
 
+// Info gets snapshot of task state
+func (t *Task) Info() Info {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	info := Info{
+		ID:            t.id,
+		URL:           t.url,
+		Type:          t.Type,
+		Status:        t.status,
+		StartTime:     t.startTime,
+		BytesReceived: t.bytes,
+		LastError:     t.lastError,
+		FailureReason: t.lastReason,
+		Reconnects:    t.reconnects,
+		Bitrate:       t.meter.rate(time.Now()) * 8,
+		TTFB:          t.ttfb,
+		DNSLookup:     t.dnsTime,
+		Failures:      make(map[string]uint32, len(t.failures)),
+		Segments:      t.segments,
+		Stalls:        t.stalls,
+		Packets:       t.packets,
+		PacketRate:    t.pktMeter.rate(time.Now()),
+		PacketsLost:   t.lost,
+		Malformed:     t.malformed,
+		Reordered:     t.reordered,
+		Duplicates:    t.duplicates,
+	}
+	if t.TransportSettings.Source != nil {
+		info.Source = t.TransportSettings.Source.String()
+	}
+	if t.segments != 0 {
+		info.SegmentLatency = t.segTime / time.Duration(t.segments)
+	}
+	for reason, count := range t.failures {
+		info.Failures[reason] = count
+	}
+	if t.analyzer != nil {
+		stats := t.analyzer.Stats()
+		info.Analysis = &stats
+	}
+	if t.player != nil {
+		stats := t.player.Stats(time.Now())
+		info.Playout = &stats
+	}
+	if t.recorder != nil {
+		info.Recorded = t.recorder.Written()
+	}
+	info.Verified = t.verified
+	info.Mismatches = t.mismatches
+	return info
+}
+
 // Stop immediately stops task
 func (t *Task) Stop() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.stopped = true
+	t.restarting = false
 	t.cancel()
+	if t.done == nil {
+		t.status = StatusStopped
+	}
 }
 
-func (t *Task) process() {
-	defer func() {
-		t.status = StatusError
-	}()
+func (t *Task) process(ctx context.Context) {
+	defer t.finish()
+
+	// task has been stopped before start
+	if ctx.Err() != nil {
+		return
+	}
 
-	req, err := http.NewRequestWithContext(t.ctx, http.MethodGet, t.url, nil)
+	streamer, err := t.newStreamer()
+	if err == nil {
+		err = t.openTransport()
+	}
 	if err != nil {
-		log.Printf("[%s] Create request failed: %s", t.url, err)
+		log.Printf("[%s] Cannot start: %s", t.url, err)
+		t.fail(FailureRequest, err)
+		t.emit(EventError, FailureRequest, err.Error())
+		return
+	}
+
+	attempt := 0
+	for {
+		connected, retry := t.session(ctx, streamer)
+		if connected {
+			attempt = 0
+		}
+		attempt++
+
+		if ctx.Err() != nil || !retry || !t.Retry.allowed(attempt) {
+			return
+		}
+
+		delay := t.Retry.backoff(attempt)
+		log.Printf("[%s] Reconnect in %s (attempt %d)", t.url, delay.Round(time.Millisecond), attempt)
+		t.reconnecting()
+		t.emit(EventReconnecting, "", fmt.Sprintf("attempt %d in %s", attempt, delay.Round(time.Millisecond)))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+	}
+}
+
+func (t *Task) newStreamer() (Streamer, error) {
+	kind := t.Type
+	if kind == "" {
+		var err error
+		if kind, err = DetectType(t.url); err != nil {
+			return nil, err
+		}
+	}
+	return newStreamer(kind)
+}
+
+// session performs one attempt of receiving stream. It returns whether connection has been established and
+// whether the failure is suitable for reconnecting
+func (t *Task) session(ctx context.Context, streamer Streamer) (connected, retry bool) {
+	s := newSession(t)
+	defer s.close()
+
+	t.emit(EventConnecting, "", "")
+
+	err := streamer.Receive(ctx, s)
+
+	// interruption of stopped task is not a failure
+	if ctx.Err() != nil {
+		return s.connected, false
+	}
+
+	var f *Failure
+	if err == nil {
+		f = &Failure{Reason: FailureEOF, Err: io.EOF, Retry: true}
+	} else if !errors.As(err, &f) {
+		f = &Failure{Reason: FailureRead, Err: err, Retry: true}
+	}
+
+	log.Printf("[%s] Session failed: %s", t.url, f)
+	t.fail(f.Reason, f.Err)
+	if f.Reason == FailureTimeout {
+		t.emit(EventTimeout, f.Reason, f.Err.Error())
+	} else {
+		t.emit(EventError, f.Reason, f.Err.Error())
+	}
+	return s.connected, f.Retry
+}
+
+func (t *Task) setStatus(status Status) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.status = status
+}
+
+func (t *Task) finish() {
+	t.mutex.Lock()
+	stopped, lastError, lastReason := t.stopped, t.lastError, t.lastReason
+	if t.recorder != nil {
+		if err := t.recorder.Close(); err != nil {
+			log.Printf("[%s] Cannot close record: %s", t.url, err)
+		}
+	}
+	if t.transport != nil {
+		t.transport.CloseIdleConnections()
+	}
+	if stopped {
+		t.status = StatusStopped
+	} else {
+		t.status = StatusError
+	}
+	t.mutex.Unlock()
+
+	if stopped {
+		t.emit(EventStopped, "", "")
+	} else {
+		t.emit(EventFailed, lastReason, lastError)
+	}
+}
+
+func (t *Task) fail(reason string, err error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if !t.stopped {
+		t.lastError = err.Error()
+		t.lastReason = reason
+		t.failures[reason]++
+	}
+}
+
+func (t *Task) observeLatency(latency time.Duration) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.samples.Requests = append(t.samples.Requests, latency)
+}
+
+func (t *Task) segmentFetched(latency time.Duration) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.segments++
+	t.segTime += latency
+	t.samples.Segments = append(t.samples.Segments, latency)
+}
+
+func (t *Task) dnsLookup(d time.Duration) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.dnsTime = d
+	t.samples.DNS = append(t.samples.DNS, d)
+}
+
+func (t *Task) stalled() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.stalls++
+}
+
+// TakeSamples returns latencies observed since the previous call
+func (t *Task) TakeSamples() Samples {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	samples := t.samples
+	t.samples = Samples{}
+	return samples
+}
+
+func (t *Task) reconnecting() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.status = StatusReconnecting
+	t.reconnects++
+}
+
+func (t *Task) received(n int) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.bytes += uint64(n)
+	t.meter.add(time.Now(), n)
+}
+
+func (t *Task) mediaReceived(data []byte) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	now := time.Now()
+	t.bytes += uint64(len(data))
+	t.meter.add(now, len(data))
+	if t.analyzer != nil {
+		t.analyzer.Feed(now, data)
+	}
+	if t.player != nil {
+		t.player.Feed(now, len(data))
+	}
+}
+
+// record writes received media to the storage. Recorder is created on the first call if recording is enabled
+func (t *Task) record(data []byte) {
+	t.mutex.Lock()
+	if !t.Record || t.Storage == nil {
+		t.mutex.Unlock()
 		return
 	}
+	if t.recorder == nil {
+		t.recorder = t.Storage.NewRecorder(t.storageDir())
+	}
+	recorder := t.recorder
+	t.mutex.Unlock()
 
-	log.Printf("[%s] Connecting...", t.url)
+	err := recorder.Write(time.Now(), data)
 
-	transport := &http.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if err != nil && !t.recordErr {
+		log.Printf("[%s] Recording failed: %s", t.url, err)
 	}
-	c := http.Client{Transport: transport}
+	t.recordErr = err != nil
+}
 
-	resp, err := c.Do(req)
+func (t *Task) storageDir() string {
+	return fmt.Sprintf("task-%d", t.id)
+}
+
+// saveSample writes captured beginning of the session to the storage
+func (t *Task) saveSample(data []byte) {
+	path, err := t.Storage.Save(t.storageDir(), "sample", data)
 	if err != nil {
-		log.Printf("[%s] Perform request failed: %s", t.url, err)
+		log.Printf("[%s] Cannot save sample: %s", t.url, err)
 		return
 	}
+	log.Printf("[%s] Sample saved: %s", t.url, path)
+}
+
+// contentReceived verifies hash of received content, error is returned if it differs from other tasks
+func (t *Task) contentReceived(c *contentHash) error {
+	sum := c.sum()
+	expected, ok := t.Contents.check(c.url, sum)
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if ok {
+		t.verified++
+		return nil
+	}
+	t.mismatches++
+	return mismatchError(c.url, sum, expected)
+}
 
-	defer resp.Body.Close()
+func (t *Task) packetReceived(payload []byte) {
+	t.mediaReceived(payload)
 
-	if resp.StatusCode != http.StatusOK {
-		log.Printf("[%s] Unexpected status code: %d", t.url, resp.StatusCode)
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.packets++
+	t.pktMeter.add(time.Now(), 1)
+}
+
+// resetAnalyzer prepares analyzer for the new session. Analyzer is created on the first call if it is enabled
+func (t *Task) resetAnalyzer() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if !t.Analyze {
 		return
 	}
+	if t.analyzer == nil {
+		t.analyzer = ts.NewAnalyzer()
+	}
+	t.analyzer.Reset()
+}
 
-	/*
-		Так как мы не можем для http.Response выставить таймаут для сокета, то
-		используем такой костыль для контролирования, что данные вообще приходят
-	*/
-	notifyCh := make(chan bool)
-	if t.Timeout != 0 {
-		go func() {
-			for {
-				select {
-				case <-t.ctx.Done():
-					return
-				case <-notifyCh:
-				case <-time.After(t.Timeout):
-					log.Printf("[%s] Read timeout expired", t.url)
-					t.cancel()
-					return
-				}
-			}
-		}()
-	}
-
-	log.Printf("[%s] Connected", t.url)
-	t.status = StatusActive
-
-	buffer := make([]byte, readBufferSize)
-	for {
-		_, err = resp.Body.Read(buffer)
-		if err != nil {
-			log.Printf("[%s] Read failed: %s", t.url, err)
-			t.cancel()
-			return
-		}
-		if t.Timeout != 0 {
-			notifyCh <- true
+// limiters returns limiters of reading rate of the task. Own limiter is created on the first call if it is enabled
+func (t *Task) limiters() []*throttle.Limiter {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	var limiters []*throttle.Limiter
+	if t.RateLimit != 0 {
+		if t.limiter == nil {
+			t.limiter = throttle.NewLimiter(t.RateLimit)
 		}
+		limiters = append(limiters, t.limiter)
+	}
+	if t.SharedLimiter != nil {
+		limiters = append(limiters, t.SharedLimiter)
 	}
+	return limiters
+}
+
+// openTransport creates own HTTP transport of the task if shared one is not set
+func (t *Task) openTransport() error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if t.Transport != nil || t.transport != nil {
+		return nil
+	}
+	var err error
+	t.transport, err = NewTransport(t.TransportSettings, t.TLS)
+	return err
+}
+
+func (t *Task) httpTransport() *Transport {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if t.Transport != nil {
+		return t.Transport
+	}
+	return t.transport
+}
+
+func (t *Task) setNominalBitrate(bps float64) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if t.player != nil {
+		t.player.SetBitrate(bps)
+	}
+}
+
+func (t *Task) packetMalformed() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.malformed++
+}
+
+func (t *Task) packetsLost(n int) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.lost += uint64(n)
+}
+
+func (t *Task) packetOutOfOrder(duplicate bool) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if duplicate {
+		t.duplicates++
+	} else {
+		t.reordered++
+	}
+}
+
+func (t *Task) nextRequest() uint64 {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.requests++
+	return t.requests
+}
+
+func (t *Task) setTTFB(ttfb time.Duration) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.ttfb = ttfb
 }
@@ -0,0 +1,85 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"net"
+	"net/url"
+	"time"
+)
+
+// tcpStreamer receives raw stream from TCP socket, e.g. tcp://host:port
+type tcpStreamer struct{}
+
+func (tcpStreamer) Receive(ctx context.Context, s *Session) error {
+	u, err := url.Parse(s.URL())
+	if err != nil {
+		return &Failure{Reason: FailureRequest, Err: err}
+	}
+
+	log.Printf("[%s] Connecting...", s.URL())
+
+	dialer := s.t.TransportSettings.dialer()
+	conn, err := dialer.DialContext(s.traced(ctx), "tcp", u.Host)
+	if err != nil {
+		return connectFailure(err)
+	}
+	defer conn.Close()
+
+	stop := closeOnDone(ctx, conn)
+	defer stop()
+
+	s.Connected()
+
+	buffer := make([]byte, s.ReadSize())
+	for {
+		setReadDeadline(conn, s.Timeout())
+		n, err := conn.Read(buffer)
+		s.MediaReceived(buffer[:n])
+		if errors.Is(err, io.EOF) {
+			s.EndContent()
+			// live stream is not expected to end
+			return &Failure{Reason: FailureEOF, Err: io.EOF, Retry: true}
+		}
+		if err != nil {
+			return readFailure(err)
+		}
+		if err = s.Throttle(ctx, n); err != nil {
+			return &Failure{Reason: FailureRead, Err: err, Retry: true}
+		}
+	}
+}
+
+// closeOnDone closes connection on cancellation of the context to unblock reading. Returned function
+// must be called when the connection is not used anymore
+func closeOnDone(ctx context.Context, conn io.Closer) func() {
+	stop := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = conn.Close()
+		case <-stop:
+		}
+	}()
+	return func() { close(stop) }
+}
+
+func setReadDeadline(conn net.Conn, timeout time.Duration) {
+	if timeout != 0 {
+		_ = conn.SetReadDeadline(time.Now().Add(timeout))
+	}
+}
+
+func isTimeout(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+func readFailure(err error) *Failure {
+	if isTimeout(err) {
+		return &Failure{Reason: FailureTimeout, Err: errors.New("read timeout expired"), Retry: true}
+	}
+	return &Failure{Reason: FailureRead, Err: err, Retry: true}
+}
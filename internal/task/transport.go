@@ -0,0 +1,193 @@
+package task
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/net/http2"
+)
+
+// HTTP protocol versions of transport
+const (
+	ProtocolHTTP1 = "http1"
+	ProtocolHTTP2 = "http2"
+)
+
+// errNoHTTP2 is returned when HTTP/2 is required but server has not negotiated it
+var errNoHTTP2 = errors.New("server does not support HTTP/2")
+
+// TransportSettings tune HTTP connections
+type TransportSettings struct {
+	// Protocol is an HTTP version: http1 (default) or http2. HTTP/2 is negotiated by ALPN for HTTPS and
+	// used with prior knowledge (h2c) for plain HTTP
+	Protocol string
+
+	// MaxConnsPerHost limits connections to one host, unlimited if zero
+	MaxConnsPerHost int
+
+	// MaxIdleConnsPerHost limits idle connections kept for reusing, default of net/http if zero
+	MaxIdleConnsPerHost int
+
+	// IdleTimeout is a time of keeping idle connection, unlimited if zero
+	IdleTimeout time.Duration
+
+	// DialTimeout limits establishing of TCP connection, unlimited if zero
+	DialTimeout time.Duration
+
+	// DisableKeepAlive makes each request use new connection
+	DisableKeepAlive bool
+
+	// Source is a local IP address of outgoing connections, chosen by system if nil
+	Source net.IP
+
+	// Hosts are addresses which are connected instead of resolving host names
+	Hosts HostOverrides
+
+	// DNSServer is an address of DNS server resolving host names, system resolver is used if empty
+	DNSServer string
+}
+
+// Validate checks settings
+func (s TransportSettings) Validate() error {
+	if s.Protocol != "" && s.Protocol != ProtocolHTTP1 && s.Protocol != ProtocolHTTP2 {
+		return fmt.Errorf("unsupported HTTP protocol: %s", s.Protocol)
+	}
+	if s.MaxConnsPerHost < 0 || s.MaxIdleConnsPerHost < 0 || s.IdleTimeout < 0 || s.DialTimeout < 0 {
+		return errors.New("transport limits must not be negative")
+	}
+	return validateDNSServer(s.DNSServer)
+}
+
+// Transport is an HTTP transport which can be shared by tasks
+type Transport struct {
+	http1 *http.Transport
+	h2c   *http2.Transport // cleartext HTTP/2, nil if HTTP/2 is not used
+	pool  *h2cPool
+}
+
+// NewTransport creates HTTP transport. TLS configuration is optional
+func NewTransport(settings TransportSettings, tlsConfig *tls.Config) (*Transport, error) {
+	if err := settings.Validate(); err != nil {
+		return nil, err
+	}
+
+	dialer := settings.dialer()
+	t := &Transport{http1: &http.Transport{
+		DialContext:         dialer.DialContext,
+		MaxConnsPerHost:     settings.MaxConnsPerHost,
+		MaxIdleConnsPerHost: settings.MaxIdleConnsPerHost,
+		IdleConnTimeout:     settings.IdleTimeout,
+		DisableKeepAlives:   settings.DisableKeepAlive,
+	}}
+	if tlsConfig != nil {
+		t.http1.TLSClientConfig = tlsConfig.Clone()
+	}
+
+	if settings.Protocol == ProtocolHTTP2 {
+		if err := http2.ConfigureTransport(t.http1); err != nil {
+			return nil, fmt.Errorf("cannot configure HTTP/2: %w", err)
+		}
+		t.pool = &h2cPool{dialer: dialer, conns: make(map[string][]*http2.ClientConn)}
+		t.h2c = &http2.Transport{AllowHTTP: true, ConnPool: t.pool}
+		t.pool.t = t.h2c
+	}
+	return t, nil
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.h2c == nil {
+		return t.http1.RoundTrip(req)
+	}
+	if req.URL.Scheme == "http" {
+		return t.h2c.RoundTrip(req)
+	}
+
+	resp, err := t.http1.RoundTrip(req)
+	if err == nil && resp.ProtoMajor != 2 {
+		_ = resp.Body.Close()
+		return nil, errNoHTTP2
+	}
+	return resp, err
+}
+
+// CloseIdleConnections closes connections which are not used by requests
+func (t *Transport) CloseIdleConnections() {
+	t.http1.CloseIdleConnections()
+	if t.pool != nil {
+		t.pool.closeIdleConnections()
+	}
+}
+
+// h2cPool keeps connections of cleartext HTTP/2. Unlike default pool of http2.Transport it dials with context
+// of request, so stopped tasks do not wait for connecting
+type h2cPool struct {
+	t      *http2.Transport
+	dialer *dialer
+
+	mutex sync.Mutex
+	conns map[string][]*http2.ClientConn // by address
+}
+
+// GetClientConn implements http2.ClientConnPool
+func (p *h2cPool) GetClientConn(req *http.Request, addr string) (*http2.ClientConn, error) {
+	p.mutex.Lock()
+	for _, cc := range p.conns[addr] {
+		if cc.CanTakeNewRequest() {
+			p.mutex.Unlock()
+			return cc, nil
+		}
+	}
+	p.mutex.Unlock()
+
+	conn, err := p.dialer.DialContext(req.Context(), "tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	cc, err := p.t.NewClientConn(conn)
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	p.mutex.Lock()
+	p.conns[addr] = append(p.conns[addr], cc)
+	p.mutex.Unlock()
+	return cc, nil
+}
+
+// MarkDead implements http2.ClientConnPool
+func (p *h2cPool) MarkDead(dead *http2.ClientConn) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	for addr, conns := range p.conns {
+		for i, cc := range conns {
+			if cc == dead {
+				p.conns[addr] = append(conns[:i], conns[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// closeIdleConnections shuts connections down, each one is closed after its requests are completed
+func (p *h2cPool) closeIdleConnections() {
+	p.mutex.Lock()
+	conns := p.conns
+	p.conns = make(map[string][]*http2.ClientConn)
+	p.mutex.Unlock()
+
+	for _, list := range conns {
+		for _, cc := range list {
+			go func(cc *http2.ClientConn) {
+				_ = cc.Shutdown(context.Background())
+			}(cc)
+		}
+	}
+}
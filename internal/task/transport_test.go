@@ -0,0 +1,95 @@
+package task
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+)
+
+func TestTransportProtocol(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, r.Proto)
+	})
+
+	tests := []struct {
+		name     string
+		protocol string
+		tls      bool
+		h2       bool // server supports HTTP/2
+		proto    string
+		failure  error
+	}{
+		{name: "HTTP/1.1", proto: "HTTP/1.1"},
+		{name: "h2c", protocol: ProtocolHTTP2, h2: true, proto: "HTTP/2.0"},
+		{name: "HTTP/2 over TLS", protocol: ProtocolHTTP2, tls: true, h2: true, proto: "HTTP/2.0"},
+		{name: "HTTP/2 is not negotiated", protocol: ProtocolHTTP2, tls: true, failure: errNoHTTP2},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			h := http.Handler(handler)
+			if test.h2 && !test.tls {
+				h = h2c.NewHandler(handler, &http2.Server{})
+			}
+			s := httptest.NewUnstartedServer(h)
+			var conns int32
+			s.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+				if state == http.StateNew {
+					atomic.AddInt32(&conns, 1)
+				}
+			}
+			s.EnableHTTP2 = test.h2
+			if test.tls {
+				s.StartTLS()
+			} else {
+				s.Start()
+			}
+			defer s.Close()
+
+			var tlsConfig *tls.Config
+			if s.TLS != nil {
+				tlsConfig = &tls.Config{RootCAs: s.Client().Transport.(*http.Transport).TLSClientConfig.RootCAs}
+			}
+			transport, err := NewTransport(TransportSettings{Protocol: test.protocol}, tlsConfig)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer transport.CloseIdleConnections()
+			client := &http.Client{Transport: transport}
+
+			// the second request must reuse the connection
+			for i := 0; i < 2; i++ {
+				resp, err := client.Get(s.URL)
+				if test.failure != nil {
+					if !errors.Is(err, test.failure) {
+						t.Fatalf("got %v, want %v", err, test.failure)
+					}
+					return
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+				body, err := io.ReadAll(resp.Body)
+				_ = resp.Body.Close()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if string(body) != test.proto {
+					t.Errorf("got %s, want %s", body, test.proto)
+				}
+			}
+			if n := atomic.LoadInt32(&conns); n != 1 {
+				t.Errorf("got %d connections, want 1", n)
+			}
+		})
+	}
+}
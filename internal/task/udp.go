@@ -0,0 +1,93 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"net/url"
+
+	"github.com/racoon-devel/downloader/internal/rtp"
+)
+
+// udpStreamer receives datagrams from UDP socket, e.g. udp://239.0.0.1:1234?iface=eth0. Multicast group is joined on
+// the interface from the URL or on the default one. If rtp is set, datagrams are RTP packets and gaps in sequence
+// numbers are accounted as lost packets, late and duplicated packets are accounted separately. Malformed RTP packets
+// are accounted and skipped
+type udpStreamer struct {
+	rtp bool
+}
+
+func (st udpStreamer) Receive(ctx context.Context, s *Session) error {
+	conn, err := listenUDP(s.URL())
+	if err != nil {
+		return &Failure{Reason: FailureConnect, Err: err, Retry: true}
+	}
+	defer conn.Close()
+
+	stop := closeOnDone(ctx, conn)
+	defer stop()
+
+	log.Printf("[%s] Listening on %s...", s.URL(), conn.LocalAddr())
+
+	var sequence rtp.Sequence
+	buffer := make([]byte, readBufferSize)
+	for {
+		setReadDeadline(conn, s.Timeout())
+		n, _, err := conn.ReadFromUDP(buffer)
+		if err != nil {
+			return readFailure(err)
+		}
+
+		if !s.connected {
+			s.Connected()
+		}
+
+		if !st.rtp {
+			s.PacketReceived(buffer[:n])
+			continue
+		}
+
+		packet, err := rtp.Parse(buffer[:n])
+		if err != nil {
+			s.PacketMalformed()
+			continue
+		}
+		lost, order := sequence.Push(packet.SequenceNumber)
+		if lost != 0 {
+			s.PacketsLost(lost)
+		}
+		if order != rtp.InOrder {
+			s.PacketOutOfOrder(order == rtp.Duplicate)
+		}
+		s.PacketReceived(packet.Payload)
+	}
+}
+
+func listenUDP(rawURL string) (*net.UDPConn, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	addr, err := net.ResolveUDPAddr("udp", u.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	if addr.IP == nil || !addr.IP.IsMulticast() {
+		return net.ListenUDP("udp", addr)
+	}
+
+	var ifi *net.Interface
+	if name := u.Query().Get("iface"); name != "" {
+		if ifi, err = net.InterfaceByName(name); err != nil {
+			return nil, err
+		}
+	}
+	conn, err := net.ListenMulticastUDP("udp", ifi, addr)
+	if err != nil {
+		return nil, fmt.Errorf("join multicast group failed: %w", err)
+	}
+	return conn, nil
+}
@@ -0,0 +1,137 @@
+package task
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+	"time"
+)
+
+func rtpPacket(seq uint16) []byte {
+	packet := make([]byte, 12+188)
+	packet[0] = 0x80
+	packet[1] = 33
+	binary.BigEndian.PutUint16(packet[2:4], seq)
+	packet[12] = 0x47
+	return packet
+}
+
+func freeUDPPort(t *testing.T) int {
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	return conn.LocalAddr().(*net.UDPAddr).Port
+}
+
+func waitFor(t *testing.T, task *Task, done func(info Info) bool) Info {
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		info := task.Info()
+		if done(info) {
+			return info
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("timeout, task state: %+v", info)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestRTPLossOverLoopback(t *testing.T) {
+	malformed := []byte{0x80, 33, 0, 1}
+
+	tests := []struct {
+		name      string
+		first     uint16
+		packets   [][]byte
+		lost      uint64
+		reordered uint64
+		malformed uint64
+	}{
+		{
+			name:    "sequential",
+			first:   1,
+			packets: [][]byte{rtpPacket(2), rtpPacket(3), rtpPacket(4)},
+		},
+		{
+			name:    "gap",
+			first:   1,
+			packets: [][]byte{rtpPacket(2), rtpPacket(5), rtpPacket(6)},
+			lost:    2,
+		},
+		{
+			name:    "wraparound",
+			first:   65534,
+			packets: [][]byte{rtpPacket(65535), rtpPacket(1), rtpPacket(2)},
+			lost:    1,
+		},
+		{
+			name:      "reordered",
+			first:     1,
+			packets:   [][]byte{rtpPacket(2), rtpPacket(4), rtpPacket(3), rtpPacket(5)},
+			lost:      1,
+			reordered: 1,
+		},
+		{
+			name:      "malformed packet is skipped",
+			first:     1,
+			packets:   [][]byte{rtpPacket(2), malformed, rtpPacket(4)},
+			lost:      1,
+			malformed: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr := fmt.Sprintf("127.0.0.1:%d", freeUDPPort(t))
+			task := NewTask(context.Background(), 1, "rtp://"+addr)
+			task.Timeout = 5 * time.Second
+
+			var wg sync.WaitGroup
+			task.Run(&wg)
+			defer wg.Wait()
+			defer task.Stop()
+
+			conn, err := net.Dial("udp", addr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer conn.Close()
+
+			// the first packet is repeated until the task listens, duplicates are not losses
+			waitFor(t, task, func(info Info) bool {
+				_, _ = conn.Write(rtpPacket(tt.first))
+				return info.Packets != 0
+			})
+			base := task.Info().Packets
+
+			for _, packet := range tt.packets {
+				if _, err = conn.Write(packet); err != nil {
+					t.Fatal(err)
+				}
+			}
+			valid := uint64(len(tt.packets)) - tt.malformed
+			info := waitFor(t, task, func(info Info) bool {
+				return info.Packets >= base+valid && info.Malformed >= tt.malformed
+			})
+
+			if info.PacketsLost != tt.lost {
+				t.Errorf("lost packets: got %d, want %d", info.PacketsLost, tt.lost)
+			}
+			if info.Reordered != tt.reordered {
+				t.Errorf("reordered packets: got %d, want %d", info.Reordered, tt.reordered)
+			}
+			if info.Malformed != tt.malformed {
+				t.Errorf("malformed packets: got %d, want %d", info.Malformed, tt.malformed)
+			}
+			if info.Status != StatusActive {
+				t.Errorf("status: got %s, want %s", info.Status, StatusActive)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package throttle
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// burstDuration is an amount of traffic allowed to be received at once, in time of receiving at the limit rate
+const burstDuration = 50 * time.Millisecond
+
+// minBurst is a lower bound of burst (bytes), so slow limits don't make reads too short
+const minBurst = 1024
+
+// Limiter is a token bucket which limits rate of reading. It is safe for concurrent use, so one limiter
+// can be shared by several readers
+type Limiter struct {
+	rate  float64 // bytes per second
+	burst int
+
+	mutex  sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// NewLimiter creates limiter with the rate in bits per second
+func NewLimiter(bps float64) *Limiter {
+	rate := bps / 8
+	burst := int(rate * burstDuration.Seconds())
+	if burst < minBurst {
+		burst = minBurst
+	}
+	return &Limiter{rate: rate, burst: burst, tokens: float64(burst), last: time.Now()}
+}
+
+// Burst returns max size of read (bytes) which does not need waiting on the full bucket
+func (l *Limiter) Burst() int {
+	return l.burst
+}
+
+// Wait takes n bytes from the bucket and blocks until the debt is paid off or the context is done
+func (l *Limiter) Wait(ctx context.Context, n int) error {
+	l.mutex.Lock()
+	now := time.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > float64(l.burst) {
+		l.tokens = float64(l.burst)
+	}
+	l.last = now
+	l.tokens -= float64(n)
+	tokens := l.tokens
+	l.mutex.Unlock()
+
+	if tokens >= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(time.Duration(-tokens / l.rate * float64(time.Second)))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
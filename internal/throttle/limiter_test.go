@@ -0,0 +1,126 @@
+package throttle
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// tolerance is an allowed delay of wake-ups over the expected time
+const tolerance = 100 * time.Millisecond
+
+func TestLimiterRate(t *testing.T) {
+	tests := []struct {
+		name    string
+		bps     float64
+		readers int
+		total   int // bytes read by each reader
+		chunk   int
+		minimum time.Duration
+	}{
+		{
+			// burst is 5000 bytes, so the rest 15000 bytes take 150ms at 100 KB/s
+			name:    "single reader",
+			bps:     800000,
+			readers: 1,
+			total:   20000,
+			chunk:   1000,
+			minimum: 150 * time.Millisecond,
+		},
+		{
+			name:    "chunks larger than burst",
+			bps:     800000,
+			readers: 1,
+			total:   20000,
+			chunk:   10000,
+			minimum: 150 * time.Millisecond,
+		},
+		{
+			// burst is 1024 bytes instead of 500, the rest 2048 bytes take 204.8ms at 10 KB/s
+			name:    "slow rate uses minimal burst",
+			bps:     80000,
+			readers: 1,
+			total:   3072,
+			chunk:   512,
+			minimum: 204800 * time.Microsecond,
+		},
+		{
+			// shared limiter divides the rate: 4 x 10000 bytes minus burst take 350ms
+			name:    "shared by readers",
+			bps:     800000,
+			readers: 4,
+			total:   10000,
+			chunk:   1000,
+			minimum: 350 * time.Millisecond,
+		},
+		{
+			name:    "within burst",
+			bps:     800000,
+			readers: 1,
+			total:   5000,
+			chunk:   1000,
+			minimum: 0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			l := NewLimiter(test.bps)
+			start := time.Now()
+
+			var wg sync.WaitGroup
+			for i := 0; i < test.readers; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for read := 0; read < test.total; read += test.chunk {
+						if err := l.Wait(context.Background(), test.chunk); err != nil {
+							t.Error(err)
+							return
+						}
+					}
+				}()
+			}
+			wg.Wait()
+
+			elapsed := time.Since(start)
+			// the last wait is rounded to the timer resolution
+			if elapsed < test.minimum-time.Millisecond || elapsed > test.minimum+tolerance {
+				t.Errorf("took %s, want about %s", elapsed, test.minimum)
+			}
+		})
+	}
+}
+
+func TestLimiterBurst(t *testing.T) {
+	tests := []struct {
+		bps   float64
+		burst int
+	}{
+		{bps: 8000000, burst: 50000},
+		{bps: 800000, burst: 5000},
+		{bps: 8000, burst: minBurst},
+	}
+
+	for _, test := range tests {
+		if burst := NewLimiter(test.bps).Burst(); burst != test.burst {
+			t.Errorf("burst of %.0f bps: got %d, want %d", test.bps, burst, test.burst)
+		}
+	}
+}
+
+func TestLimiterCancel(t *testing.T) {
+	l := NewLimiter(8000)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	// 1 KB/s limit makes the debt of 10 KB wait for about 9 seconds
+	if err := l.Wait(ctx, 10000); err != context.DeadlineExceeded {
+		t.Errorf("got %v, want %v", err, context.DeadlineExceeded)
+	}
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond+tolerance {
+		t.Errorf("cancelled wait took %s", elapsed)
+	}
+}
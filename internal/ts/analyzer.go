@@ -0,0 +1,218 @@
+package ts
+
+import (
+	"bytes"
+	"time"
+)
+
+// PacketSize is a size of MPEG-TS packet
+const PacketSize = 188
+
+const (
+	syncByte = 0x47
+	nullPID  = 0x1fff
+
+	// pcrClock is a frequency of program clock reference
+	pcrClock = 27000000
+
+	// pcrModulus is an overflow value of PCR (33 bits base multiplied by 300)
+	pcrModulus = (1 << 33) * 300
+
+	// maxPCRInterval is a gap between PCRs which is considered as discontinuity
+	maxPCRInterval = time.Second
+)
+
+// PIDStats is a statistic of elementary stream
+type PIDStats struct {
+	Packets          uint64
+	ContinuityErrors uint64
+
+	// Bitrate is an average bits per second between the first and the last packet of the PID
+	Bitrate float64
+}
+
+// Stats is a statistic of transport stream
+type Stats struct {
+	Packets          uint64
+	SyncLosses       uint64
+	ContinuityErrors uint64
+
+	// PCRJitterMax and PCRJitterAvg are deviations of PCR intervals from arrival intervals
+	PCRJitterMax time.Duration
+	PCRJitterAvg time.Duration
+
+	PIDs map[uint16]PIDStats
+}
+
+type pidState struct {
+	packets     uint64
+	errors      uint64
+	first, last time.Time
+
+	cc      byte
+	hasCC   bool
+	pcr     uint64
+	pcrTime time.Time
+	hasPCR  bool
+}
+
+// Analyzer checks sanity of MPEG-TS: sync bytes, continuity counters and PCR jitter. It is not thread-safe
+type Analyzer struct {
+	packets    uint64
+	syncLosses uint64
+	errors     uint64
+	jitterMax  time.Duration
+	jitterSum  time.Duration
+	jitterN    int64
+	pids       map[uint16]*pidState
+
+	partial []byte
+	synced  bool
+}
+
+// NewAnalyzer creates analyzer
+func NewAnalyzer() *Analyzer {
+	return &Analyzer{pids: make(map[uint16]*pidState), partial: make([]byte, 0, PacketSize)}
+}
+
+// Reset forgets state of the stream, so the next data are not checked against previous ones. Counters are kept
+func (a *Analyzer) Reset() {
+	a.partial = a.partial[:0]
+	a.synced = false
+	for _, st := range a.pids {
+		st.hasCC = false
+		st.hasPCR = false
+	}
+}
+
+// Feed analyzes portion of the stream received at the moment
+func (a *Analyzer) Feed(now time.Time, data []byte) {
+	if n := len(a.partial); n != 0 {
+		need := PacketSize - n
+		if len(data) < need {
+			a.partial = append(a.partial, data...)
+			return
+		}
+		a.partial = append(a.partial, data[:need]...)
+		data = data[need:]
+		a.packet(now, a.partial)
+		a.partial = a.partial[:0]
+	}
+
+	for len(data) != 0 {
+		if data[0] != syncByte {
+			if a.synced {
+				a.syncLosses++
+				a.synced = false
+			}
+			i := bytes.IndexByte(data, syncByte)
+			if i < 0 {
+				return
+			}
+			data = data[i:]
+			continue
+		}
+
+		// sync byte may occur in payload, so the next packet must confirm synchronization
+		if !a.synced && len(data) > PacketSize && data[PacketSize] != syncByte {
+			data = data[1:]
+			continue
+		}
+
+		if len(data) < PacketSize {
+			a.partial = append(a.partial, data...)
+			return
+		}
+		a.packet(now, data[:PacketSize])
+		data = data[PacketSize:]
+	}
+}
+
+// Stats returns snapshot of the counters
+func (a *Analyzer) Stats() Stats {
+	stats := Stats{
+		Packets:          a.packets,
+		SyncLosses:       a.syncLosses,
+		ContinuityErrors: a.errors,
+		PCRJitterMax:     a.jitterMax,
+		PIDs:             make(map[uint16]PIDStats, len(a.pids)),
+	}
+	if a.jitterN != 0 {
+		stats.PCRJitterAvg = a.jitterSum / time.Duration(a.jitterN)
+	}
+	for pid, st := range a.pids {
+		ps := PIDStats{Packets: st.packets, ContinuityErrors: st.errors}
+		if elapsed := st.last.Sub(st.first); elapsed > 0 {
+			ps.Bitrate = float64(st.packets*PacketSize*8) / elapsed.Seconds()
+		}
+		stats.PIDs[pid] = ps
+	}
+	return stats
+}
+
+func (a *Analyzer) packet(now time.Time, p []byte) {
+	a.synced = true
+	a.packets++
+
+	pid := uint16(p[1]&0x1f)<<8 | uint16(p[2])
+	if pid == nullPID {
+		return
+	}
+
+	st, ok := a.pids[pid]
+	if !ok {
+		st = &pidState{first: now}
+		a.pids[pid] = st
+	}
+	st.packets++
+	st.last = now
+
+	control := (p[3] >> 4) & 0x3
+	cc := p[3] & 0x0f
+
+	discontinuity := false
+	if control&0x2 != 0 && p[4] != 0 {
+		flags := p[5]
+		discontinuity = flags&0x80 != 0
+		if flags&0x10 != 0 && p[4] >= 7 {
+			a.pcr(now, st, parsePCR(p[6:12]), discontinuity)
+		}
+	}
+
+	// continuity counter is incremented only by packets with payload, one duplicate is allowed
+	if control&0x1 != 0 {
+		if st.hasCC && !discontinuity && cc != st.cc && cc != (st.cc+1)&0x0f {
+			a.errors++
+			st.errors++
+		}
+		st.cc = cc
+		st.hasCC = true
+	}
+}
+
+func (a *Analyzer) pcr(now time.Time, st *pidState, pcr uint64, discontinuity bool) {
+	if st.hasPCR && !discontinuity {
+		delta := (pcr + pcrModulus - st.pcr) % pcrModulus
+		interval := time.Duration(delta * 1000 / (pcrClock / 1000000))
+		if interval <= maxPCRInterval {
+			jitter := now.Sub(st.pcrTime) - interval
+			if jitter < 0 {
+				jitter = -jitter
+			}
+			if jitter > a.jitterMax {
+				a.jitterMax = jitter
+			}
+			a.jitterSum += jitter
+			a.jitterN++
+		}
+	}
+	st.pcr = pcr
+	st.pcrTime = now
+	st.hasPCR = true
+}
+
+func parsePCR(b []byte) uint64 {
+	base := uint64(b[0])<<25 | uint64(b[1])<<17 | uint64(b[2])<<9 | uint64(b[3])<<1 | uint64(b[4])>>7
+	ext := uint64(b[4]&0x01)<<8 | uint64(b[5])
+	return base*300 + ext
+}
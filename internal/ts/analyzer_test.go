@@ -0,0 +1,199 @@
+package ts
+
+import (
+	"testing"
+	"time"
+)
+
+// packet builds TS packet of the PID with payload only
+func packet(pid uint16, cc byte) []byte {
+	p := make([]byte, PacketSize)
+	p[0] = syncByte
+	p[1] = byte(pid >> 8)
+	p[2] = byte(pid)
+	p[3] = 0x10 | cc&0x0f
+	return p
+}
+
+// pcrPacket builds TS packet with adaptation field carrying PCR (27 MHz ticks)
+func pcrPacket(pid uint16, cc byte, pcr uint64, discontinuity bool) []byte {
+	p := packet(pid, cc)
+	p[3] |= 0x20
+	p[4] = 7
+	p[5] = 0x10
+	if discontinuity {
+		p[5] |= 0x80
+	}
+	base, ext := pcr/300, pcr%300
+	p[6] = byte(base >> 25)
+	p[7] = byte(base >> 17)
+	p[8] = byte(base >> 9)
+	p[9] = byte(base >> 1)
+	p[10] = byte(base<<7) | byte(ext>>8)
+	p[11] = byte(ext)
+	return p
+}
+
+func concat(packets ...[]byte) []byte {
+	var data []byte
+	for _, p := range packets {
+		data = append(data, p...)
+	}
+	return data
+}
+
+func TestContinuity(t *testing.T) {
+	adaptationOnly := packet(0x100, 5)
+	adaptationOnly[3] = 0x20 | 5
+
+	tests := []struct {
+		name       string
+		data       []byte
+		packets    uint64
+		errors     uint64
+		syncLosses uint64
+	}{
+		{
+			name:    "continuous",
+			data:    concat(packet(0x100, 14), packet(0x100, 15), packet(0x100, 0), packet(0x100, 1)),
+			packets: 4,
+		},
+		{
+			name:    "duplicate is allowed",
+			data:    concat(packet(0x100, 1), packet(0x100, 1), packet(0x100, 2)),
+			packets: 3,
+		},
+		{
+			name:    "lost packet",
+			data:    concat(packet(0x100, 1), packet(0x100, 3), packet(0x100, 4)),
+			packets: 3,
+			errors:  1,
+		},
+		{
+			name:    "counters are per PID",
+			data:    concat(packet(0x100, 1), packet(0x101, 7), packet(0x100, 2), packet(0x101, 8)),
+			packets: 4,
+		},
+		{
+			name:    "packet without payload does not increment counter",
+			data:    concat(packet(0x100, 5), adaptationOnly, packet(0x100, 6)),
+			packets: 3,
+		},
+		{
+			name:    "discontinuity indicator",
+			data:    concat(packet(0x100, 1), pcrPacket(0x100, 9, 0, true), packet(0x100, 10)),
+			packets: 3,
+		},
+		{
+			name:    "null packets are not checked",
+			data:    concat(packet(nullPID, 1), packet(nullPID, 9)),
+			packets: 2,
+		},
+		{
+			name:       "sync loss",
+			data:       concat(packet(0x100, 1), packet(0x100, 2), []byte{0, 1, 2}, packet(0x100, 3), packet(0x100, 4)),
+			packets:    4,
+			syncLosses: 1,
+		},
+	}
+
+	now := time.Now()
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// the same data split into pieces of different sizes must give the same result
+			for _, chunk := range []int{len(test.data), PacketSize, 100, 7} {
+				a := NewAnalyzer()
+				for data := test.data; len(data) != 0; {
+					n := chunk
+					if n > len(data) {
+						n = len(data)
+					}
+					a.Feed(now, data[:n])
+					data = data[n:]
+				}
+
+				stats := a.Stats()
+				if stats.Packets != test.packets || stats.ContinuityErrors != test.errors || stats.SyncLosses != test.syncLosses {
+					t.Errorf("chunk %d: got packets %d, errors %d, sync losses %d, want %d, %d, %d", chunk,
+						stats.Packets, stats.ContinuityErrors, stats.SyncLosses, test.packets, test.errors, test.syncLosses)
+				}
+			}
+		})
+	}
+}
+
+func TestPCRJitter(t *testing.T) {
+	const tick = pcrClock / 1000 // 1ms
+
+	type arrival struct {
+		at            time.Duration
+		pcr           uint64
+		discontinuity bool
+	}
+
+	tests := []struct {
+		name     string
+		arrivals []arrival
+		max      time.Duration
+		avg      time.Duration
+	}{
+		{
+			name:     "no jitter",
+			arrivals: []arrival{{0, 0, false}, {40 * time.Millisecond, 40 * tick, false}, {80 * time.Millisecond, 80 * tick, false}},
+		},
+		{
+			name:     "late and early packets",
+			arrivals: []arrival{{0, 0, false}, {42 * time.Millisecond, 40 * tick, false}, {76 * time.Millisecond, 80 * tick, false}},
+			max:      6 * time.Millisecond,
+			avg:      4 * time.Millisecond,
+		},
+		{
+			name:     "PCR wraparound",
+			arrivals: []arrival{{0, pcrModulus - 20*tick, false}, {43 * time.Millisecond, 20 * tick, false}},
+			max:      3 * time.Millisecond,
+			avg:      3 * time.Millisecond,
+		},
+		{
+			name:     "discontinuity is skipped",
+			arrivals: []arrival{{0, 0, false}, {40 * time.Millisecond, 500 * tick, true}, {80 * time.Millisecond, 540 * tick, false}},
+		},
+		{
+			name:     "too long interval is skipped",
+			arrivals: []arrival{{0, 0, false}, {2 * time.Second, 2000 * tick, false}},
+		},
+	}
+
+	start := time.Now()
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a := NewAnalyzer()
+			for i, p := range test.arrivals {
+				a.Feed(start.Add(p.at), pcrPacket(0x100, byte(i), p.pcr, p.discontinuity))
+			}
+
+			stats := a.Stats()
+			if stats.PCRJitterMax != test.max || stats.PCRJitterAvg != test.avg {
+				t.Errorf("got max %s, avg %s, want %s, %s", stats.PCRJitterMax, stats.PCRJitterAvg, test.max, test.avg)
+			}
+			if stats.ContinuityErrors != 0 {
+				t.Errorf("unexpected continuity errors: %d", stats.ContinuityErrors)
+			}
+		})
+	}
+}
+
+func TestPIDBitrate(t *testing.T) {
+	a := NewAnalyzer()
+	start := time.Now()
+	for i := 0; i <= 10; i++ {
+		a.Feed(start.Add(time.Duration(i)*100*time.Millisecond), packet(0x100, byte(i)))
+	}
+
+	stats := a.Stats().PIDs[0x100]
+	if stats.Packets != 11 {
+		t.Errorf("got %d packets, want 11", stats.Packets)
+	}
+	if expected := float64(11 * PacketSize * 8); stats.Bitrate != expected {
+		t.Errorf("got bitrate %f, want %f", stats.Bitrate, expected)
+	}
+}
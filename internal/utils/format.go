@@ -0,0 +1,76 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FormatBytes returns human-readable representation of bytes count
+func FormatBytes(bytes uint64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	div, exp := uint64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
+
+// FormatBitrate returns human-readable representation of bits per second
+func FormatBitrate(bps float64) string {
+	const unit = 1000
+	if bps < unit {
+		return fmt.Sprintf("%.0f bps", bps)
+	}
+	exp := 0
+	for bps >= unit*unit && exp < 3 {
+		bps /= unit
+		exp++
+	}
+	return fmt.Sprintf("%.2f %cbps", bps/unit, "kMGT"[exp])
+}
+
+// ParseBitrate parses bits per second with optional suffix k, M or G, e.g. "4M", "500kbps", "1000000"
+func ParseBitrate(s string) (float64, error) {
+	value := strings.TrimSuffix(s, "bps")
+	multiplier := 1.0
+	if n := len(value); n != 0 {
+		switch value[n-1] {
+		case 'k', 'K':
+			multiplier = 1e3
+		case 'M':
+			multiplier = 1e6
+		case 'G':
+			multiplier = 1e9
+		}
+		if multiplier != 1 {
+			value = value[:n-1]
+		}
+	}
+	bps, err := strconv.ParseFloat(value, 64)
+	if err != nil || bps < 0 {
+		return 0, fmt.Errorf("invalid bitrate: %s", s)
+	}
+	return bps * multiplier, nil
+}
+
+// ParseBytes parses bytes count with optional suffix K, M or G (powers of 1024), e.g. "100M"
+func ParseBytes(s string) (uint64, error) {
+	value := strings.TrimSuffix(strings.TrimSuffix(s, "B"), "i")
+	multiplier := uint64(1)
+	if n := len(value); n != 0 {
+		if exp := strings.IndexByte("KMG", value[n-1]); exp >= 0 {
+			multiplier = 1 << (10 * (exp + 1))
+			value = value[:n-1]
+		}
+	}
+	bytes, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid size: %s", s)
+	}
+	return bytes * multiplier, nil
+}